@@ -0,0 +1,98 @@
+package met
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultConcurrency is the number of concurrent requests made by batch
+// operations when their options do not specify a concurrency.
+const DefaultConcurrency = 8
+
+// ObjectsByIDOptions encapsulates arguments for ObjectsByID and
+// StreamObjectsByID.
+type ObjectsByIDOptions struct {
+	// Concurrency is the maximum number of concurrent Object requests. If
+	// unspecified, DefaultConcurrency is used.
+	Concurrency int
+}
+
+func (options ObjectsByIDOptions) concurrency() int {
+	if options.Concurrency > 0 {
+		return options.Concurrency
+	}
+	return DefaultConcurrency
+}
+
+// ObjectByIDResult is the outcome of fetching a single object in a batch.
+type ObjectByIDResult struct {
+	// Index is the position of ObjectID in the requested slice of IDs.
+	Index int
+	// ObjectID is the requested Object ID.
+	ObjectID int
+	// Object is the fetched record, or nil if Err is non-nil.
+	Object *ObjectResult
+	// Err is the error fetching this object, if any. Use errors.Is with
+	// ErrObjectNotFound to identify deleted objects.
+	Err error
+}
+
+// StreamObjectsByID fetches the objects with the specified IDs, with bounded
+// concurrency, and sends each result on the returned channel as it completes.
+// Results arrive in completion order; use ObjectByIDResult.Index to restore
+// input order.
+//
+// The channel is closed when every object has been fetched or ctx is done.
+// Callers that stop receiving before the channel closes must cancel ctx to
+// release the fetching goroutines.
+func (c *Client) StreamObjectsByID(ctx context.Context, ids []int, options ObjectsByIDOptions) <-chan ObjectByIDResult {
+	out := make(chan ObjectByIDResult)
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < options.concurrency(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				obj, err := c.ObjectContext(ctx, ObjectOptions{ObjectID: ids[i]})
+				select {
+				case out <- ObjectByIDResult{Index: i, ObjectID: ids[i], Object: obj, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(out)
+		defer wg.Wait()
+		defer close(indices)
+		for i := range ids {
+			select {
+			case indices <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// ObjectsByID fetches the objects with the specified IDs, with bounded
+// concurrency, and returns their results in input order. Failures fetching
+// individual objects are reported in each result's Err; the returned error is
+// non-nil only if the batch as a whole failed, e.g. because ctx was cancelled.
+// In that case, objects that were not fetched have a nil Object and either a
+// nil Err or the context's error.
+func (c *Client) ObjectsByID(ctx context.Context, ids []int, options ObjectsByIDOptions) ([]ObjectByIDResult, error) {
+	results := make([]ObjectByIDResult, len(ids))
+	for i, id := range ids {
+		results[i] = ObjectByIDResult{Index: i, ObjectID: id}
+	}
+	for result := range c.StreamObjectsByID(ctx, ids, options) {
+		results[result.Index] = result
+	}
+	return results, ctx.Err()
+}
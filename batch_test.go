@@ -0,0 +1,68 @@
+package met
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// objectServer serves records for positive Object IDs and 404s otherwise.
+// Every search and objects listing contains IDs -1 through 43.
+func objectServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search" || r.URL.Path == "/objects" {
+			ids := make([]string, 45)
+			for i := range ids {
+				ids[i] = fmt.Sprint(i - 1)
+			}
+			fmt.Fprintf(w, `{"total": %d, "objectIDs": [%s]}`, len(ids), strings.Join(ids, ","))
+			return
+		}
+		var id int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/objects/"), "%d", &id); err != nil || id <= 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"objectID": %d, "title": "Object %d"}`, id, id)
+	}))
+}
+
+func TestObjectsByID(t *testing.T) {
+	server := objectServer()
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	ids := []int{5, 3, -1, 8, 1, 2, 13, 21}
+	results, err := c.ObjectsByID(context.Background(), ids, ObjectsByIDOptions{Concurrency: 3})
+	if err != nil {
+		t.Fatalf("Unexpected batch error: %v", err)
+	}
+	for i, result := range results {
+		if result.Index != i || result.ObjectID != ids[i] {
+			t.Errorf("Result %d out of order: %+v", i, result)
+		}
+		if ids[i] < 0 {
+			if !errors.Is(result.Err, ErrObjectNotFound) {
+				t.Errorf("Expected not found error for ID %d, got: %v", ids[i], result.Err)
+			}
+		} else if result.Err != nil || result.Object.ObjectID != ids[i] {
+			t.Errorf("Unexpected result for ID %d: %+v", ids[i], result)
+		}
+	}
+}
+
+func TestObjectsByIDCancelled(t *testing.T) {
+	server := objectServer()
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.ObjectsByID(ctx, []int{1, 2, 3}, ObjectsByIDOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation error, got: %v", err)
+	}
+}
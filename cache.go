@@ -0,0 +1,178 @@
+package met
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Endpoint identifies a Met API endpoint.
+type Endpoint string
+
+// The Met API endpoints.
+const (
+	EndpointObjects     Endpoint = "objects"
+	EndpointObject      Endpoint = "object"
+	EndpointDepartments Endpoint = "departments"
+	EndpointSearch      Endpoint = "search"
+)
+
+// DefaultCacheTTL returns the cache lifetimes used by a Client with a Cache but
+// no CacheTTL. Object records and departments change rarely; listings and
+// search results change whenever any object does.
+func DefaultCacheTTL() map[Endpoint]time.Duration {
+	return map[Endpoint]time.Duration{
+		EndpointObjects:     time.Hour,
+		EndpointObject:      24 * time.Hour,
+		EndpointDepartments: 24 * time.Hour,
+		EndpointSearch:      time.Hour,
+	}
+}
+
+// CacheEntry is a cached Met API response.
+type CacheEntry struct {
+	// Body is the response body.
+	Body []byte `json:"body"`
+	// Expires is the time after which the entry is stale.
+	Expires time.Time `json:"expires"`
+	// ETag is the response's ETag header, if any. Client revalidates stale
+	// entries with an ETag using If-None-Match.
+	ETag string `json:"etag,omitempty"`
+	// LastModified is the response's Last-Modified header, if any. Client
+	// revalidates stale entries with a LastModified using If-Modified-Since.
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// Cache stores Met API responses keyed by request URL. Implementations must be
+// safe for concurrent use. Caches should retain stale entries: Client checks
+// CacheEntry.Expires before using an entry, and revalidates stale entries with
+// conditional requests.
+type Cache interface {
+	// Get returns the entry for key, if one is stored.
+	Get(key string) (*CacheEntry, bool)
+	// Set stores entry for key. Failures to store are not reported, since a
+	// Client can always refetch an uncached response.
+	Set(key string, entry *CacheEntry)
+}
+
+// MemoryCache is an in-memory Cache that evicts the least recently used entry
+// when full.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	elements map[string]*list.Element
+}
+
+// memoryCacheItem is the value of each element of MemoryCache.order.
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache returns a MemoryCache holding up to capacity entries.
+// NewMemoryCache panics if capacity is not positive.
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("met: non-positive capacity %d for NewMemoryCache", capacity))
+	}
+	return &MemoryCache{
+		capacity: capacity,
+		order:    list.New(),
+		elements: map[string]*list.Element{},
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.elements[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*memoryCacheItem).entry, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, entry *CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.elements[key]; ok {
+		el.Value.(*memoryCacheItem).entry = entry
+		c.order.MoveToFront(el)
+		return
+	}
+	c.elements[key] = c.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.elements, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// DiskCache is a Cache storing each entry as a file in a directory.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a DiskCache storing entries in dir, which is created if
+// it does not exist.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// path returns the path of the file storing the entry for key.
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements Cache.
+func (c *DiskCache) Get(key string) (*CacheEntry, bool) {
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	entry := new(CacheEntry)
+	if err := json.Unmarshal(b, entry); err != nil {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Set implements Cache.
+func (c *DiskCache) Set(key string, entry *CacheEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	// Write atomically, so concurrent readers never see a partial entry.
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err != nil || closeErr != nil {
+		return
+	}
+	os.Rename(tmp.Name(), c.path(key))
+}
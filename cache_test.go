@@ -0,0 +1,151 @@
+package met
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", &CacheEntry{Body: []byte("a")})
+	c.Set("b", &CacheEntry{Body: []byte("b")})
+	c.Get("a")
+	c.Set("c", &CacheEntry{Body: []byte("c")})
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Least recently used entry should be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if entry, ok := c.Get(key); !ok || string(entry.Body) != key {
+			t.Errorf("Expected entry for %q", key)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", c.Len())
+	}
+}
+
+func TestNewMemoryCacheRejectsNonPositiveCapacity(t *testing.T) {
+	for _, capacity := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for capacity %d", capacity)
+				}
+			}()
+			NewMemoryCache(capacity)
+		}()
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expires := time.Now().Add(time.Hour).Round(0)
+	c.Set("https://example.com/objects/1", &CacheEntry{Body: []byte(`{"objectID":1}`), Expires: expires})
+
+	// A new DiskCache over the same directory sees the entry.
+	reopened, _ := NewDiskCache(dir)
+	entry, ok := reopened.Get("https://example.com/objects/1")
+	if !ok || string(entry.Body) != `{"objectID":1}` || !entry.Expires.Equal(expires) {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	if _, ok := reopened.Get("https://example.com/objects/2"); ok {
+		t.Errorf("Unexpected entry for uncached key")
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/departments":
+			w.Write([]byte(`{"departments": [{"departmentId": 1, "displayName": "American Decorative Arts"}]}`))
+		default:
+			w.Write([]byte(`{"objectID": 1}`))
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.Cache = NewMemoryCache(10)
+	c.CacheTTL = map[Endpoint]time.Duration{EndpointDepartments: time.Hour}
+
+	for i := 0; i < 3; i++ {
+		depts, err := c.Departments()
+		if err != nil || len(depts.Departments) != 1 {
+			t.Fatalf("Unexpected result: %+v, %v", depts, err)
+		}
+		if _, err := c.Object(ObjectOptions{ObjectID: 1}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// Departments is requested once; Object, with no TTL, every time.
+	if requests != 4 {
+		t.Errorf("Expected 4 requests, got %d", requests)
+	}
+}
+
+func TestClientRevalidatesStaleEntries(t *testing.T) {
+	var full, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") != "" {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Wed, 01 Jan 2020 00:00:00 GMT")
+		w.Write([]byte(`{"objectID": 1, "title": "Cached"}`))
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.Cache = NewMemoryCache(10)
+	// Entries expire immediately, so every request revalidates.
+	c.CacheTTL = map[Endpoint]time.Duration{EndpointObject: time.Nanosecond}
+
+	for i := 0; i < 3; i++ {
+		obj, err := c.Object(ObjectOptions{ObjectID: 1})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		} else if obj.Title != "Cached" {
+			t.Errorf("Unexpected title %q", obj.Title)
+		}
+	}
+	if full != 1 || notModified != 2 {
+		t.Errorf("Expected 1 full and 2 conditional responses, got %d and %d", full, notModified)
+	}
+}
+
+// corruptCache holds a single entry for every key.
+type corruptCache struct{ entry *CacheEntry }
+
+func (c corruptCache) Get(key string) (*CacheEntry, bool) { return c.entry, true }
+func (c corruptCache) Set(key string, entry *CacheEntry)  {}
+
+func TestClientIgnoresPartlyDecodedEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"objectID": 1}`))
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+	// The title decodes before objectID fails to.
+	c.Cache = corruptCache{&CacheEntry{
+		Body:    []byte(`{"title": "Corrupt", "objectID": "one"}`),
+		Expires: time.Now().Add(time.Hour),
+	}}
+	c.CacheTTL = map[Endpoint]time.Duration{EndpointObject: time.Hour}
+
+	obj, err := c.Object(ObjectOptions{ObjectID: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if obj.ObjectID != 1 || obj.Title != "" {
+		t.Errorf("Expected only the network response's fields, got: %+v", obj)
+	}
+}
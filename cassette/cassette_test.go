@@ -0,0 +1,70 @@
+package cassette
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func get(t *testing.T, c *http.Client, url string) (int, string, error) {
+	t.Helper()
+	resp, err := c.Get(url)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body), err
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write([]byte(r.URL.Path))
+	}))
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	rec, err := New(path, Record, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, p := range []string{"/objects", "/missing"} {
+		if _, _, err := get(t, rec.Client(), server.URL+p); err != nil {
+			t.Fatalf("Unexpected error recording: %v", err)
+		}
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Unexpected error saving: %v", err)
+	}
+	server.Close()
+
+	replay, err := New(path, Replay, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !replay.RecordedAt().Equal(rec.RecordedAt()) {
+		t.Errorf("RecordedAt not preserved: %s != %s", replay.RecordedAt(), rec.RecordedAt())
+	}
+	if status, body, err := get(t, replay.Client(), server.URL+"/objects"); err != nil || status != 200 || body != "/objects" {
+		t.Errorf("Unexpected replay: %d %q %v", status, body, err)
+	}
+	if status, _, err := get(t, replay.Client(), server.URL+"/missing"); err != nil || status != 404 {
+		t.Errorf("Unexpected replay: %d %v", status, err)
+	}
+	if _, _, err := get(t, replay.Client(), server.URL+"/objects"); !errors.Is(err, ErrUnmatched) {
+		t.Errorf("Expected repeated request to be unmatched, got: %v", err)
+	}
+	if _, _, err := get(t, replay.Client(), server.URL+"/departments"); !errors.Is(err, ErrUnmatched) {
+		t.Errorf("Expected unrecorded request to be unmatched, got: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 live requests, got %d", requests)
+	}
+}
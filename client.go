@@ -1,10 +1,15 @@
 package met
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"reflect"
+	"time"
 )
 
 // defaultRoot is the public root of the Met API.
@@ -15,6 +20,29 @@ type Client struct {
 	*http.Client
 	// RootURL is the Met API root. If unspecified, Client uses defaultRoot.
 	RootURL *url.URL
+	// Retry configures retries for requests that fail for transient reasons.
+	// If nil, Client does not retry failed requests. See DefaultRetryPolicy.
+	Retry *RetryPolicy
+	// Limiter, if non-nil, throttles every request attempt made by Client. See
+	// NewMetRateLimiter.
+	Limiter *RateLimiter
+	// Cache, if non-nil, stores responses for reuse by later requests.
+	Cache Cache
+	// CacheTTL maps endpoints to how long their responses are cached.
+	// Responses from endpoints with no positive TTL are not cached. If nil,
+	// Client uses DefaultCacheTTL.
+	CacheTTL map[Endpoint]time.Duration
+	// DisableCoalescing stops Client from sharing one request among
+	// concurrent identical calls. By default, such calls share both the
+	// upstream request and the decoded result, so callers making concurrent
+	// requests should treat results as read-only.
+	DisableCoalescing bool
+	// Vocabularies, if non-nil, makes Search strict: searches whose Media or
+	// GeoLocations contain values unknown to Vocabularies fail with an
+	// *UnknownValueError before any request is made. See MetVocabularies.
+	Vocabularies *Vocabularies
+
+	flights flightGroup
 }
 
 // NewClient constructs a Met API client.
@@ -36,79 +64,208 @@ func (c *Client) copyRootURL() *url.URL {
 // restriction on metadata update dates and/or department membership
 // specified in the request options.
 func (c *Client) Objects(options ObjectsOptions) (*ObjectsResult, error) {
+	return c.ObjectsContext(context.Background(), options)
+}
+
+// ObjectsContext is like Objects, but the request is bound to ctx.
+func (c *Client) ObjectsContext(ctx context.Context, options ObjectsOptions) (*ObjectsResult, error) {
 	u := c.copyRootURL()
 	u.Path += "objects"
 	u.RawQuery = options.toQuery().Encode()
 
-	result := new(ObjectsResult)
-	if err := c.makeRequest(u, result); err != nil {
-		return nil, err
-	}
-	return result, nil
+	return request[ObjectsResult](ctx, c, EndpointObjects, u)
 }
 
 // Object returns a record for an object, containing all open access data about
 // that object, including its image (if the image is available under Open
 // Access).
 func (c *Client) Object(options ObjectOptions) (*ObjectResult, error) {
+	return c.ObjectContext(context.Background(), options)
+}
+
+// ObjectContext is like Object, but the request is bound to ctx.
+func (c *Client) ObjectContext(ctx context.Context, options ObjectOptions) (*ObjectResult, error) {
 	u := c.copyRootURL()
 	u.Path += fmt.Sprintf("objects/%d", options.ObjectID)
 
-	result := new(ObjectResult)
-	if err := c.makeRequest(u, result); err != nil {
-		return nil, err
-	}
-	return result, nil
+	return request[ObjectResult](ctx, c, EndpointObject, u)
 }
 
 // Departments returns a listing of all valid departments.
 func (c *Client) Departments() (*DepartmentsResult, error) {
+	return c.DepartmentsContext(context.Background())
+}
+
+// DepartmentsContext is like Departments, but the request is bound to ctx.
+func (c *Client) DepartmentsContext(ctx context.Context) (*DepartmentsResult, error) {
 	u := c.copyRootURL()
 	u.Path += "departments"
 
-	result := new(DepartmentsResult)
-	if err := c.makeRequest(u, result); err != nil {
-		return nil, err
-	}
-	return result, nil
+	return request[DepartmentsResult](ctx, c, EndpointDepartments, u)
 }
 
 // Search returns a listing of all Object IDs for objects with metadata
 // matching the specified options.
 func (c *Client) Search(options SearchOptions) (*ObjectsResult, error) {
+	return c.SearchContext(context.Background(), options)
+}
+
+// SearchContext is like Search, but the request is bound to ctx.
+func (c *Client) SearchContext(ctx context.Context, options SearchOptions) (*ObjectsResult, error) {
+	if c.Vocabularies != nil {
+		if err := c.Vocabularies.Check(options); err != nil {
+			return nil, err
+		}
+	}
 	u := c.copyRootURL()
 	u.Path += "search"
 	u.RawQuery = options.toQuery().Encode()
 
-	result := new(ObjectsResult)
-	if err := c.makeRequest(u, result); err != nil {
+	return request[ObjectsResult](ctx, c, EndpointSearch, u)
+}
+
+// request makes a request to u, an endpoint URL, and returns the decoded
+// response. Concurrent identical requests are coalesced unless
+// c.DisableCoalescing is set.
+func request[T any](ctx context.Context, c *Client, endpoint Endpoint, u *url.URL) (*T, error) {
+	fetch := func(ctx context.Context) (interface{}, error) {
+		result := new(T)
+		if err := c.makeRequest(ctx, endpoint, u, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	var v interface{}
+	var err error
+	if c.DisableCoalescing {
+		v, err = fetch(ctx)
+	} else {
+		v, err = c.flights.do(ctx, u.String(), fetch)
+	}
+	if err != nil {
 		return nil, err
 	}
-	return result, nil
+	return v.(*T), nil
 }
 
-func (c *Client) makeRequest(u *url.URL, v interface{}) error {
+// makeRequest GETs u, an endpoint URL, and decodes the JSON response body into
+// v. Responses are served from and stored in c.Cache, and failed requests are
+// retried per c.Retry. Cancelling ctx aborts the request.
+func (c *Client) makeRequest(ctx context.Context, endpoint Endpoint, u *url.URL, v interface{}) error {
+	key := u.String()
+	ttl := c.cacheTTL(endpoint)
+	var cached *CacheEntry
+	if ttl > 0 {
+		if entry, ok := c.Cache.Get(key); ok {
+			if time.Now().Before(entry.Expires) && decodeBody(entry.Body, v) == nil {
+				return nil
+			}
+			cached = entry
+		}
+	}
+
+	var entry *CacheEntry
+	err := c.Retry.withRetries(ctx, func() (err error) {
+		entry, err = c.attemptRequest(ctx, u, v, cached)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if ttl > 0 {
+		entry.Expires = time.Now().Add(ttl)
+		c.Cache.Set(key, entry)
+	}
+	return nil
+}
+
+// cacheTTL returns the cache lifetime for responses from endpoint, or zero if
+// they are not cached.
+func (c *Client) cacheTTL(endpoint Endpoint) time.Duration {
+	if c.Cache == nil {
+		return 0
+	}
+	if c.CacheTTL == nil {
+		return DefaultCacheTTL()[endpoint]
+	}
+	return c.CacheTTL[endpoint]
+}
+
+// attemptRequest makes a single attempt at the request described in
+// makeRequest, returning the response as an unexpired CacheEntry. If cached is
+// non-nil, the request is conditional on cached being out of date; if the Met
+// API reports it is current, cached's body is decoded and reused.
+func (c *Client) attemptRequest(ctx context.Context, u *url.URL, v interface{}, cached *CacheEntry) (*CacheEntry, error) {
+	if c.Limiter != nil {
+		if err := c.Limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
 	httpClient := c.Client
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 
-	resp, err := checkStatus(httpClient.Get(u.String()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed constructing request: %w", err)
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	resp, err := httpClient.Do(req)
+	if err == nil && resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		if err = decodeBody(cached.Body, v); err != nil {
+			return nil, fmt.Errorf("failed decoding cached response body: %w", err)
+		}
+		entry := *cached
+		return &entry, nil
+	}
+	resp, err = checkStatus(resp, err)
 	if err != nil {
-		return fmt.Errorf("bad response: %w", err)
+		return nil, fmt.Errorf("bad response: %w", err)
 	}
 	defer resp.Body.Close()
-	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
-		return fmt.Errorf("failed decoding response body: %w", err)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading response body: %w", err)
+	}
+	if err = decodeBody(body, v); err != nil {
+		return nil, fmt.Errorf("failed decoding response body: %w", err)
+	}
+	return &CacheEntry{
+		Body:         body,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// decodeBody decodes the JSON body into v, a non-nil pointer. v is left
+// unchanged if body fails to decode, so a partial decode never leaks fields into
+// a later one.
+func decodeBody(body []byte, v interface{}) error {
+	scratch := reflect.New(reflect.TypeOf(v).Elem())
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(scratch.Interface()); err != nil {
+		return err
 	}
+	reflect.ValueOf(v).Elem().Set(scratch.Elem())
 	return nil
 }
 
+// checkStatus converts non-200 responses into *APIError values. The body of a
+// non-200 response is closed.
 func checkStatus(res *http.Response, err error) (*http.Response, error) {
 	if err != nil {
 		return res, err
-	} else if res != nil && res.StatusCode != 200 {
-		return res, fmt.Errorf("got non-200 response code: %d", res.StatusCode)
+	} else if res != nil && res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, newAPIError(res)
 	}
 	return res, nil
 }
@@ -0,0 +1,101 @@
+package met
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client whose RootURL points at server.
+func newTestClient(t *testing.T, server *httptest.Server) *Client {
+	t.Helper()
+	root, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("Failed parsing test server URL: %s", err)
+	}
+	c := NewClient(server.Client())
+	c.RootURL = root
+	return c
+}
+
+func TestContextCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	c := newTestClient(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.ObjectContext(ctx, ObjectOptions{ObjectID: 1})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded error, got: %v", err)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/objects/1":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "ObjectID not found"}`))
+		case "/departments":
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	_, err := c.Object(ObjectOptions{ObjectID: 1})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got: %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Body != `{"message": "ObjectID not found"}` {
+		t.Errorf("Unexpected APIError contents: %+v", apiErr)
+	}
+	if !errors.Is(err, ErrObjectNotFound) || errors.Is(err, ErrServerError) {
+		t.Errorf("404 should match only ErrObjectNotFound: %v", err)
+	}
+
+	_, err = c.Departments()
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("429 should match ErrRateLimited: %v", err)
+	} else if errors.As(err, &apiErr); apiErr.RetryAfter != 3*time.Second {
+		t.Errorf("Expected 3s RetryAfter, got %s", apiErr.RetryAfter)
+	}
+
+	_, err = c.Search(SearchOptions{Q: "sunflowers"})
+	if !errors.Is(err, ErrServerError) {
+		t.Errorf("502 should match ErrServerError: %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"-1":                            0,
+		"Wed, 01 Jan 2020 00:01:00 GMT": time.Minute,
+		"Tue, 31 Dec 2019 00:00:00 GMT": 0,
+		"soon":                          0,
+	}
+	for value, expected := range cases {
+		if got := parseRetryAfter(value, now); got != expected {
+			t.Errorf("parseRetryAfter(%q) = %s, expected %s", value, got, expected)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package met
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup deduplicates concurrent calls with the same key, so that they
+// share a single execution and its result.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is an in-progress or completed call.
+type flight struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+	// waiters counts the callers waiting on the flight; the flight's context
+	// is cancelled if every waiter gives up.
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do executes fn, unless a call with the same key is in flight, in which case
+// it waits for and returns that call's result. fn runs with a context that
+// carries ctx's values and is cancelled only when every caller waiting on it
+// has had its own context cancelled.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*flight{}
+	}
+	f, ok := g.calls[key]
+	if ok {
+		f.waiters++
+	} else {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		g.calls[key] = f
+		go func() {
+			f.val, f.err = fn(flightCtx)
+			g.mu.Lock()
+			g.forget(key, f)
+			g.mu.Unlock()
+			cancel()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		if f.waiters--; f.waiters == 0 {
+			// Later callers must not join the abandoned flight.
+			g.forget(key, f)
+			f.cancel()
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// forget removes f from the group, if it is still the flight for key. g.mu
+// must be held.
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.calls[key] == f {
+		delete(g.calls, key)
+	}
+}
@@ -0,0 +1,82 @@
+package met
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingServer returns a server that counts object requests and blocks them
+// until release is closed.
+func blockingServer(release <-chan struct{}) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Write([]byte(`{"objectID": 1, "title": "Shared"}`))
+	}))
+	return server, &requests
+}
+
+func TestCoalescing(t *testing.T) {
+	release := make(chan struct{})
+	server, requests := blockingServer(release)
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	var wg sync.WaitGroup
+	results := make([]*ObjectResult, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = c.Object(ObjectOptions{ObjectID: 1})
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if *requests != 1 {
+		t.Errorf("Expected 1 upstream request, got %d", *requests)
+	}
+	for _, r := range results {
+		if r != results[0] || r.Title != "Shared" {
+			t.Errorf("Expected every caller to share one result, got %+v", r)
+		}
+	}
+}
+
+func TestCoalescingCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server, _ := blockingServer(release)
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	// A cancelled caller does not fail other callers sharing its request.
+	cancelled, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := c.ObjectContext(cancelled, ObjectOptions{ObjectID: 1})
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	go func() {
+		_, err := c.ObjectContext(context.Background(), ObjectOptions{ObjectID: 1})
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancelled caller to fail, got: %v", err)
+	}
+	close(release)
+	if err := <-errs; err != nil {
+		t.Errorf("Expected remaining caller to succeed, got: %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package met
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// CompoundSearchOptions encapsulates arguments for Client.CompoundSearch,
+// which combines the results of several searches with set operations. The Met
+// API accepts one query per search, so e.g. objects matching "sunflowers" that
+// are on view, but not in Drawings and Prints, are found by:
+//
+//	res, err := c.CompoundSearch(CompoundSearchOptions{
+//	  All:  []SearchOptions{{Q: "sunflowers", IsOnView: true}},
+//	  None: []SearchOptions{{Q: "sunflowers", DepartmentID: 9}},
+//	})
+//
+// A result must match every search in All, at least one search in Any, and
+// no search in None. At least one of All and Any must be non-empty.
+type CompoundSearchOptions struct {
+	// All lists searches that every result must match.
+	All []SearchOptions
+	// Any lists searches of which every result must match at least one.
+	Any []SearchOptions
+	// None lists searches that no result may match.
+	None []SearchOptions
+	// Concurrency is the maximum number of concurrent Search requests. If
+	// unspecified, DefaultConcurrency is used.
+	Concurrency int
+}
+
+func (options CompoundSearchOptions) concurrency() int {
+	if options.Concurrency > 0 {
+		return options.Concurrency
+	}
+	return DefaultConcurrency
+}
+
+// CompoundSearch runs the searches in options and combines their results.
+// See CompoundSearchOptions.
+func (c *Client) CompoundSearch(options CompoundSearchOptions) (*ObjectsResult, error) {
+	return c.CompoundSearchContext(context.Background(), options)
+}
+
+// CompoundSearchContext is like CompoundSearch, with a context. The searches
+// run concurrently; if any fails, the others are cancelled and the first
+// error is returned. The combined ObjectIDs are in ascending order, and Total
+// is their number.
+func (c *Client) CompoundSearchContext(ctx context.Context, options CompoundSearchOptions) (*ObjectsResult, error) {
+	if len(options.All) == 0 && len(options.Any) == 0 {
+		return nil, fmt.Errorf("%w: compound search has no All or Any searches", ErrInvalidSearch)
+	}
+	searches := make([]SearchOptions, 0, len(options.All)+len(options.Any)+len(options.None))
+	searches = append(append(append(searches, options.All...), options.Any...), options.None...)
+	results, err := c.searchAll(ctx, searches, options.concurrency())
+	if err != nil {
+		return nil, err
+	}
+	allOf, results := results[:len(options.All)], results[len(options.All):]
+	anyOf, noneOf := results[:len(options.Any)], results[len(options.Any):]
+
+	// counts tracks the number of All searches each candidate matches, plus one
+	// if it matches any Any search.
+	counts := map[int]int{}
+	for _, res := range allOf {
+		for _, id := range uniqueIDs(res) {
+			counts[id]++
+		}
+	}
+	if len(anyOf) > 0 {
+		union := map[int]bool{}
+		for _, res := range anyOf {
+			for _, id := range res.ObjectIDs {
+				union[id] = true
+			}
+		}
+		for id := range union {
+			counts[id]++
+		}
+	}
+	want := len(allOf)
+	if len(anyOf) > 0 {
+		want++
+	}
+	for _, res := range noneOf {
+		for _, id := range res.ObjectIDs {
+			delete(counts, id)
+		}
+	}
+
+	var ids []int
+	for id, n := range counts {
+		if n == want {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return &ObjectsResult{Total: len(ids), ObjectIDs: ids}, nil
+}
+
+// searchAll runs searches with bounded concurrency, returning their results
+// in order. It stops at the first error.
+func (c *Client) searchAll(ctx context.Context, searches []SearchOptions, concurrency int) ([]*ObjectsResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*ObjectsResult, len(searches))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for i, options := range searches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			res, err := c.SearchContext(ctx, options)
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("failed searching for %q: %w", options.Q, err)
+					cancel()
+				})
+				return
+			}
+			results[i] = res
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, ctx.Err()
+}
+
+// uniqueIDs returns res.ObjectIDs without duplicates.
+func uniqueIDs(res *ObjectsResult) []int {
+	seen := make(map[int]bool, len(res.ObjectIDs))
+	ids := make([]int, 0, len(res.ObjectIDs))
+	for _, id := range res.ObjectIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,66 @@
+package met
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestCompoundSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("q") {
+		case "sunflowers":
+			w.Write([]byte(`{"total": 4, "objectIDs": [1, 2, 3, 4]}`))
+		case "onview":
+			w.Write([]byte(`{"total": 3, "objectIDs": [2, 3, 5]}`))
+		case "prints":
+			w.Write([]byte(`{"total": 1, "objectIDs": [3]}`))
+		case "irises":
+			w.Write([]byte(`{"total": 2, "objectIDs": [4, 6]}`))
+		case "empty":
+			w.Write([]byte(`{"total": 0, "objectIDs": null}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	cases := []struct {
+		options  CompoundSearchOptions
+		expected []int
+	}{
+		{CompoundSearchOptions{
+			All:  []SearchOptions{{Q: "sunflowers"}, {Q: "onview"}},
+			None: []SearchOptions{{Q: "prints"}},
+		}, []int{2}},
+		{CompoundSearchOptions{
+			Any: []SearchOptions{{Q: "onview"}, {Q: "irises"}},
+		}, []int{2, 3, 4, 5, 6}},
+		{CompoundSearchOptions{
+			All: []SearchOptions{{Q: "sunflowers"}},
+			Any: []SearchOptions{{Q: "onview"}, {Q: "irises"}},
+		}, []int{2, 3, 4}},
+		{CompoundSearchOptions{
+			All: []SearchOptions{{Q: "sunflowers"}, {Q: "empty"}},
+		}, nil},
+	}
+	for _, tc := range cases {
+		res, err := c.CompoundSearch(tc.options)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if !slices.Equal(res.ObjectIDs, tc.expected) || res.Total != len(tc.expected) {
+			t.Errorf("Expected %v, got %v (total %d)", tc.expected, res.ObjectIDs, res.Total)
+		}
+	}
+
+	if _, err := c.CompoundSearch(CompoundSearchOptions{None: []SearchOptions{{Q: "prints"}}}); err == nil {
+		t.Errorf("Expected error for compound search with only None searches")
+	}
+	if _, err := c.CompoundSearch(CompoundSearchOptions{All: []SearchOptions{{Q: "sunflowers"}, {Q: "invalid"}}}); err == nil {
+		t.Errorf("Expected error for failed search")
+	}
+}
@@ -0,0 +1,221 @@
+package met
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// CSVReader reads ObjectResult records from the Met's Open Access CSV dump,
+// MetObjects.csv (https://github.com/metmuseum/openaccess), which contains the
+// same objects the API serves.
+//
+// Columns are mapped onto the ObjectResult fields of the same name. Where an
+// object has several constituents, the dump's Artist columns hold
+// pipe-delimited values, one per constituent; CSVReader uses them to populate
+// Constituents and takes the Artist fields from the first constituent. Tags
+// are likewise read from the pipe-delimited Tags columns.
+//
+// The dump omits image URLs and measurements, so the corresponding
+// ObjectResult fields are left empty.
+type CSVReader struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+// NewCSVReader returns a CSVReader reading from r, after reading the header
+// row.
+func NewCSVReader(r io.Reader) (*CSVReader, error) {
+	cr := csv.NewReader(r)
+	cr.LazyQuotes = true
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed reading CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// The dump begins with a byte order mark.
+		columns[strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")] = i
+	}
+	if _, ok := columns["Object ID"]; !ok {
+		return nil, errors.New("CSV header has no Object ID column")
+	}
+	return &CSVReader{r: cr, columns: columns}, nil
+}
+
+// Read returns the next object record, or io.EOF when there are none left.
+func (r *CSVReader) Read() (*ObjectResult, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	line, _ := r.r.FieldPos(0)
+	obj, err := r.parse(record)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", line, err)
+	}
+	return obj, nil
+}
+
+// All returns an iterator over the remaining object records. Iteration stops
+// after the first error.
+func (r *CSVReader) All() iter.Seq2[*ObjectResult, error] {
+	return func(yield func(*ObjectResult, error) bool) {
+		for {
+			obj, err := r.Read()
+			if err == io.EOF {
+				return
+			}
+			if !yield(obj, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// field returns the value of the named column in record, or the empty string
+// if the column is absent.
+func (r *CSVReader) field(record []string, name string) string {
+	if i, ok := r.columns[name]; ok && i < len(record) {
+		return strings.TrimSpace(record[i])
+	}
+	return ""
+}
+
+func (r *CSVReader) parse(record []string) (*ObjectResult, error) {
+	get := func(name string) string { return r.field(record, name) }
+	var errs []error
+	parseBool := func(name string) bool {
+		v := get(name)
+		if v == "" {
+			return false
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s %q", name, v))
+		}
+		return b
+	}
+	parseInt := func(name string) int {
+		v := get(name)
+		if v == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s %q", name, v))
+		}
+		return n
+	}
+
+	obj := &ObjectResult{
+		ObjectID:              parseInt("Object ID"),
+		IsHighlight:           parseBool("Is Highlight"),
+		AccessionNumber:       get("Object Number"),
+		AccessionYear:         get("AccessionYear"),
+		IsPublicDomain:        parseBool("Is Public Domain"),
+		Department:            get("Department"),
+		ObjectName:            get("Object Name"),
+		Title:                 get("Title"),
+		Culture:               get("Culture"),
+		Period:                get("Period"),
+		Dynasty:               get("Dynasty"),
+		Reign:                 get("Reign"),
+		Portfolio:             get("Portfolio"),
+		ObjectDate:            get("Object Date"),
+		ObjectBeginDate:       parseInt("Object Begin Date"),
+		ObjectEndDate:         parseInt("Object End Date"),
+		Medium:                get("Medium"),
+		Dimensions:            get("Dimensions"),
+		CreditLine:            get("Credit Line"),
+		GeographyType:         get("Geography Type"),
+		City:                  get("City"),
+		State:                 get("State"),
+		County:                get("County"),
+		Country:               get("Country"),
+		Region:                get("Region"),
+		Subregion:             get("Subregion"),
+		Locale:                get("Locale"),
+		Locus:                 get("Locus"),
+		Excavation:            get("Excavation"),
+		River:                 get("River"),
+		Classification:        get("Classification"),
+		RightsAndReproduction: get("Rights and Reproduction"),
+		LinkResource:          get("Link Resource"),
+		ObjectURL:             get("Link Resource"),
+		MetadataDate:          get("Metadata Date"),
+		Repository:            get("Repository"),
+		ObjectWikidataURL:     get("Object Wikidata URL"),
+		IsTimelineWork:        parseBool("Is Timeline Work"),
+		GalleryNumber:         get("Gallery Number"),
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// Each constituent's values occupy the same position in every
+	// pipe-delimited Artist column.
+	split := func(name string) []string { return splitPipes(get(name)) }
+	names := split("Artist Display Name")
+	roles, prefixes, bios := split("Artist Role"), split("Artist Prefix"), split("Artist Display Bio")
+	suffixes, alphaSorts := split("Artist Suffix"), split("Artist Alpha Sort")
+	nationalities, beginDates, endDates := split("Artist Nationality"), split("Artist Begin Date"), split("Artist End Date")
+	genders, ulanURLs, wikidataURLs := split("Artist Gender"), split("Artist ULAN URL"), split("Artist Wikidata URL")
+	for i, name := range names {
+		obj.Constituents = append(obj.Constituents, Constituent{
+			Name:        name,
+			Role:        at(roles, i),
+			UlanURL:     at(ulanURLs, i),
+			WikidataURL: at(wikidataURLs, i),
+			Gender:      at(genders, i),
+		})
+	}
+	obj.ArtistRole = at(roles, 0)
+	obj.ArtistPrefix = at(prefixes, 0)
+	obj.ArtistDisplayName = at(names, 0)
+	obj.ArtistDisplayBio = at(bios, 0)
+	obj.ArtistSuffix = at(suffixes, 0)
+	obj.ArtistAlphaSort = at(alphaSorts, 0)
+	obj.ArtistNationality = at(nationalities, 0)
+	obj.ArtistBeginDate = at(beginDates, 0)
+	obj.ArtistEndDate = at(endDates, 0)
+	obj.ArtistGender = at(genders, 0)
+	obj.ArtistUlanURL = at(ulanURLs, 0)
+	obj.ArtistWikidataURL = at(wikidataURLs, 0)
+
+	terms, aatURLs, tagWikidataURLs := split("Tags"), split("Tags AAT URL"), split("Tags Wikidata URL")
+	for i, term := range terms {
+		obj.Tags = append(obj.Tags, Tag{
+			Term:        term,
+			AatURL:      at(aatURLs, i),
+			WikidataURL: at(tagWikidataURLs, i),
+		})
+	}
+	return obj, nil
+}
+
+// splitPipes splits a pipe-delimited CSV value. The empty string has no
+// values.
+func splitPipes(s string) []string {
+	if s == "" {
+		return nil
+	}
+	values := strings.Split(s, "|")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
+
+// at returns values[i], or the empty string if i is out of range.
+func at(values []string, i int) string {
+	if i < len(values) {
+		return values[i]
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package met
+
+import (
+	"strings"
+	"testing"
+)
+
+const testCSV = "\ufeffObject Number,Is Highlight,Is Timeline Work,Is Public Domain,Object ID,Gallery Number,Department,Object Name,Title,Artist Role,Artist Display Name,Artist Gender,Artist ULAN URL,Object Begin Date,Object End Date,Medium,Link Resource,Tags,Tags AAT URL\n" +
+	`1979.206.1,True,False,True,1,,American Decorative Arts,Coin,One-dollar Liberty Head Coin,Maker|Engraver,James Barton Longacre|Christian Gobrecht,|Female,http://vocab.getty.edu/page/ulan/1|,1853,1853,Gold,http://www.metmuseum.org/art/collection/search/1,Animals|Men,http://vocab.getty.edu/page/aat/300249525|http://vocab.getty.edu/page/aat/300025928` + "\n" +
+	`49.30,False,True,True,436535,822,European Paintings,Painting,"Wheat Field with Cypresses",Artist,Vincent van Gogh,,,1889,1889,Oil on canvas,http://www.metmuseum.org/art/collection/search/436535,,` + "\n"
+
+func TestCSVReader(t *testing.T) {
+	r, err := NewCSVReader(strings.NewReader(testCSV))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var objects []*ObjectResult
+	for obj, err := range r.All() {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		objects = append(objects, obj)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("Expected 2 objects, got %d", len(objects))
+	}
+
+	coin := objects[0]
+	if coin.ObjectID != 1 || !coin.IsHighlight || coin.IsTimelineWork || coin.AccessionNumber != "1979.206.1" {
+		t.Errorf("Unexpected scalar fields: %+v", coin)
+	}
+	if coin.ArtistDisplayName != "James Barton Longacre" || coin.ArtistRole != "Maker" || coin.ArtistUlanURL != "http://vocab.getty.edu/page/ulan/1" {
+		t.Errorf("Artist fields should describe the first constituent: %+v", coin)
+	}
+	if len(coin.Constituents) != 2 || coin.Constituents[1] != (Constituent{Name: "Christian Gobrecht", Role: "Engraver", Gender: "Female"}) {
+		t.Errorf("Unexpected constituents: %+v", coin.Constituents)
+	}
+	if len(coin.Tags) != 2 || coin.Tags[1].Term != "Men" || coin.Tags[1].AatURL != "http://vocab.getty.edu/page/aat/300025928" {
+		t.Errorf("Unexpected tags: %+v", coin.Tags)
+	}
+
+	painting := objects[1]
+	if painting.Title != "Wheat Field with Cypresses" || painting.ObjectBeginDate != 1889 || painting.GalleryNumber != "822" {
+		t.Errorf("Unexpected painting: %+v", painting)
+	}
+	if len(painting.Constituents) != 1 || painting.Tags != nil {
+		t.Errorf("Unexpected constituents or tags: %+v, %+v", painting.Constituents, painting.Tags)
+	}
+}
+
+func TestCSVReaderInvalid(t *testing.T) {
+	if _, err := NewCSVReader(strings.NewReader("Title,Medium\n")); err == nil {
+		t.Errorf("Expected error for header without Object ID")
+	}
+	r, _ := NewCSVReader(strings.NewReader("Object ID,Is Highlight\nabc,maybe\n"))
+	if _, err := r.Read(); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected error identifying line 2, got: %v", err)
+	}
+}
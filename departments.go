@@ -1,5 +1,13 @@
 package met
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
 // DepartmentsResult is a listing of all departments. See
 // https://metmuseum.github.io/#departments
 type DepartmentsResult struct {
@@ -14,3 +22,137 @@ type Department struct {
 	// DisplayName is this department's name.
 	DisplayName string `json:"displayName"`
 }
+
+// MetDepartments returns a snapshot of the Met's curatorial departments as of
+// this writing. It does not include departments added since, so prefer
+// Client.Departments for an authoritative listing. Each call returns a new
+// slice.
+func MetDepartments() []Department {
+	return append([]Department(nil), metDepartments...)
+}
+
+var metDepartments = []Department{
+	{DepartmentID: 1, DisplayName: "American Decorative Arts"},
+	{DepartmentID: 3, DisplayName: "Ancient Near Eastern Art"},
+	{DepartmentID: 4, DisplayName: "Arms and Armor"},
+	{DepartmentID: 5, DisplayName: "Arts of Africa, Oceania, and the Americas"},
+	{DepartmentID: 6, DisplayName: "Asian Art"},
+	{DepartmentID: 7, DisplayName: "The Cloisters"},
+	{DepartmentID: 8, DisplayName: "The Costume Institute"},
+	{DepartmentID: 9, DisplayName: "Drawings and Prints"},
+	{DepartmentID: 10, DisplayName: "Egyptian Art"},
+	{DepartmentID: 11, DisplayName: "European Paintings"},
+	{DepartmentID: 12, DisplayName: "European Sculpture and Decorative Arts"},
+	{DepartmentID: 13, DisplayName: "Greek and Roman Art"},
+	{DepartmentID: 14, DisplayName: "Islamic Art"},
+	{DepartmentID: 15, DisplayName: "The Robert Lehman Collection"},
+	{DepartmentID: 16, DisplayName: "The Libraries"},
+	{DepartmentID: 17, DisplayName: "Medieval Art"},
+	{DepartmentID: 18, DisplayName: "Musical Instruments"},
+	{DepartmentID: 19, DisplayName: "Photographs"},
+	{DepartmentID: 21, DisplayName: "Modern Art"},
+}
+
+// departmentNamer returns a function resolving department IDs to names
+// among departments.
+func departmentNamer(departments []Department) func(int) (string, bool) {
+	return func(id int) (string, bool) {
+		for _, d := range departments {
+			if d.DepartmentID == id {
+				return d.DisplayName, true
+			}
+		}
+		return "", false
+	}
+}
+
+// DepartmentRegistry looks up departments by ID or by name, e.g. to resolve
+// ObjectResult.Department to a DepartmentID for ObjectsOptions.DepartmentIDs
+// or SearchOptions.DepartmentID. It is safe for concurrent use.
+type DepartmentRegistry struct {
+	// source, if non-nil, is the Source from which Refresh lists departments.
+	source Source
+
+	mu          sync.RWMutex
+	departments []Department
+	byID        map[int]Department
+	// byName maps lowercase department names to departments.
+	byName map[string]Department
+}
+
+// NewDepartmentRegistry returns a DepartmentRegistry of departments, e.g.
+// MetDepartments(). It cannot be refreshed.
+func NewDepartmentRegistry(departments []Department) *DepartmentRegistry {
+	r := &DepartmentRegistry{}
+	r.set(departments)
+	return r
+}
+
+// LoadDepartmentRegistry returns a DepartmentRegistry of the departments
+// listed by src, e.g. a Client. Refresh lists them again.
+func LoadDepartmentRegistry(ctx context.Context, src Source) (*DepartmentRegistry, error) {
+	r := &DepartmentRegistry{source: src}
+	if err := r.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Refresh replaces the registry's departments with those currently listed by
+// its Source. On error, the registry is unchanged. A Client with a Cache may
+// answer from its cached listing; see DefaultCacheTTL.
+func (r *DepartmentRegistry) Refresh(ctx context.Context) error {
+	if r.source == nil {
+		return errors.New("department registry has no source to refresh from")
+	}
+	res, err := r.source.DepartmentsContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed listing departments: %w", err)
+	}
+	r.set(res.Departments)
+	return nil
+}
+
+func (r *DepartmentRegistry) set(departments []Department) {
+	byID := make(map[int]Department, len(departments))
+	byName := make(map[string]Department, len(departments))
+	for _, d := range departments {
+		byID[d.DepartmentID] = d
+		byName[strings.ToLower(strings.TrimSpace(d.DisplayName))] = d
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.departments = append([]Department(nil), departments...)
+	r.byID, r.byName = byID, byName
+}
+
+// Departments returns the registered departments, in the order listed.
+func (r *DepartmentRegistry) Departments() []Department {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Department(nil), r.departments...)
+}
+
+// ByID returns the department with the specified ID, and false if there is
+// none.
+func (r *DepartmentRegistry) ByID(id int) (Department, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.byID[id]
+	return d, ok
+}
+
+// ByName returns the department with the specified display name, ignoring
+// case and surrounding whitespace, and false if there is none.
+func (r *DepartmentRegistry) ByName(name string) (Department, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.byName[strings.ToLower(strings.TrimSpace(name))]
+	return d, ok
+}
+
+// ForObject returns the department named by obj.Department, and false if
+// there is none.
+func (r *DepartmentRegistry) ForObject(obj *ObjectResult) (Department, bool) {
+	return r.ByName(obj.Department)
+}
@@ -0,0 +1,55 @@
+package met
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDepartmentRegistry(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		departments := testDepartments
+		if atomic.AddInt32(&requests, 1) > 1 {
+			departments = append(departments, Department{DepartmentID: 9, DisplayName: "Drawings and Prints"})
+		}
+		json.NewEncoder(w).Encode(DepartmentsResult{Departments: departments})
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+	ctx := context.Background()
+
+	r, err := LoadDepartmentRegistry(ctx, c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d, ok := r.ByName(" european PAINTINGS"); !ok || d.DepartmentID != 11 {
+		t.Errorf("Unexpected department by name: %v, %t", d, ok)
+	}
+	if d, ok := r.ByID(1); !ok || d.DisplayName != "American Decorative Arts" {
+		t.Errorf("Unexpected department by ID: %v, %t", d, ok)
+	}
+	if d, ok := r.ForObject(&ObjectResult{Department: "European Paintings"}); !ok || d.DepartmentID != 11 {
+		t.Errorf("Unexpected department for object: %v, %t", d, ok)
+	}
+	if _, ok := r.ByName("Drawings and Prints"); ok {
+		t.Errorf("Expected unknown department before refresh")
+	}
+
+	if err := r.Refresh(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if d, ok := r.ByName("drawings and prints"); !ok || d.DepartmentID != 9 {
+		t.Errorf("Unexpected department after refresh: %v, %t", d, ok)
+	}
+	if n := len(r.Departments()); n != 3 {
+		t.Errorf("Expected 3 departments, got %d", n)
+	}
+
+	if err := NewDepartmentRegistry(MetDepartments()).Refresh(ctx); err == nil {
+		t.Errorf("Expected error refreshing registry without a source")
+	}
+}
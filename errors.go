@@ -0,0 +1,108 @@
+package met
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for classes of Met API failure. Errors returned by Client
+// methods for non-200 responses are *APIError values, which match these
+// sentinels with errors.Is:
+//
+//	obj, err := c.Object(ObjectOptions{ObjectID: id})
+//	if errors.Is(err, ErrObjectNotFound) {
+//	  // Skip deleted objects.
+//	}
+var (
+	// ErrObjectNotFound matches 404 responses, which the Met API returns for
+	// invalid or deleted Object IDs.
+	ErrObjectNotFound = errors.New("met: object not found")
+	// ErrForbidden matches 403 responses, which the Met API returns when it
+	// blocks a client for making too many requests.
+	ErrForbidden = errors.New("met: forbidden")
+	// ErrRateLimited matches 429 responses.
+	ErrRateLimited = errors.New("met: rate limited")
+	// ErrServerError matches 5xx responses.
+	ErrServerError = errors.New("met: server error")
+)
+
+// maxErrorBodyLength is the maximum number of bytes of a response body
+// recorded in an APIError.
+const maxErrorBodyLength = 512
+
+// APIError describes a non-200 response from the Met API.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// URL is the requested URL.
+	URL string
+	// Body is an excerpt of the response body, at most maxErrorBodyLength
+	// bytes.
+	Body string
+	// RetryAfter is the delay requested by the response's Retry-After header,
+	// or zero if the header was absent or invalid.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("got non-200 response code %d from %s", e.StatusCode, e.URL)
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// Is reports whether the APIError belongs to the class of failure identified
+// by the sentinel error target.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrObjectNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500 && e.StatusCode <= 599
+	}
+	return false
+}
+
+// newAPIError constructs an APIError from a non-200 response. It consumes,
+// but does not close, the response body.
+func newAPIError(res *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodyLength))
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Body:       strings.TrimSpace(string(body)),
+		RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), time.Now()),
+	}
+	if res.Request != nil && res.Request.URL != nil {
+		apiErr.URL = res.Request.URL.String()
+	}
+	return apiErr
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a number
+// of seconds or an HTTP date, relative to now.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -1,5 +1,5 @@
 module github.com/lukasschwab/met
 
-go 1.15
+go 1.23
 
 require github.com/lukasschwab/optional v0.0.1
@@ -0,0 +1,218 @@
+package met
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Sink receives records collected by a Harvester.
+type Sink interface {
+	// Write stores obj. Harvester calls Write from a single goroutine.
+	Write(ctx context.Context, obj *ObjectResult) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(ctx context.Context, obj *ObjectResult) error
+
+// Write calls f(ctx, obj).
+func (f SinkFunc) Write(ctx context.Context, obj *ObjectResult) error {
+	return f(ctx, obj)
+}
+
+// JSONLinesSink is a Sink writing each record as a line of JSON.
+type JSONLinesSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONLinesSink returns a JSONLinesSink writing to w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{enc: json.NewEncoder(w)}
+}
+
+// Write implements Sink.
+func (s *JSONLinesSink) Write(ctx context.Context, obj *ObjectResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(obj)
+}
+
+// Harvester copies every object listed by the Objects endpoint to a Sink. It
+// records each completed Object ID in a checkpoint file, so a harvest
+// interrupted by a crash or cancellation resumes where it left off when run
+// again with the same CheckpointPath.
+//
+// A record is written to the Sink before its ID is checkpointed, so records
+// are delivered at least once: an object written just before a crash is
+// written again on resumption.
+type Harvester struct {
+	// Client fetches objects.
+	Client *Client
+	// Sink receives the harvested records.
+	Sink Sink
+	// CheckpointPath is the path of the checkpoint file, which is created if
+	// it does not exist. If unspecified, Harvester does not checkpoint.
+	CheckpointPath string
+	// Options restricts the harvested objects.
+	Options ObjectsOptions
+	// Concurrency is the maximum number of concurrent Object requests. If
+	// unspecified, DefaultConcurrency is used.
+	Concurrency int
+}
+
+// HarvestStats summarizes a Harvester run.
+type HarvestStats struct {
+	// Listed is the number of Object IDs listed by the Objects endpoint.
+	Listed int
+	// Resumed is the number of listed objects skipped because the checkpoint
+	// recorded them as complete.
+	Resumed int
+	// Written is the number of records written to the Sink.
+	Written int
+	// Missing lists objects the Met API reported as not found. They are
+	// checkpointed as complete.
+	Missing []int
+	// Failed maps objects that could not be fetched to their errors. They are
+	// not checkpointed, so the next run retries them.
+	Failed map[int]error
+}
+
+// Run harvests every object not already recorded in the checkpoint. It
+// returns an error if listing objects, checkpointing, or writing to the Sink
+// fails, or if ctx is done; failures fetching individual objects are recorded
+// in the returned HarvestStats instead.
+func (h *Harvester) Run(ctx context.Context) (*HarvestStats, error) {
+	done, length, err := readCheckpoint(h.CheckpointPath)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := openCheckpoint(h.CheckpointPath, length)
+	if err != nil {
+		return nil, err
+	}
+	defer checkpoint.Close()
+
+	listing, err := h.Client.ObjectsContext(ctx, h.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing objects: %w", err)
+	}
+	stats := &HarvestStats{Listed: len(listing.ObjectIDs), Failed: map[int]error{}}
+	var pending []int
+	for _, id := range listing.ObjectIDs {
+		if done[id] {
+			stats.Resumed++
+		} else {
+			pending = append(pending, id)
+		}
+	}
+
+	// Cancel outstanding fetches if the harvest stops early.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := h.Client.StreamObjectsByID(ctx, pending, ObjectsByIDOptions{Concurrency: h.Concurrency})
+	for result := range results {
+		switch {
+		case errors.Is(result.Err, ErrObjectNotFound):
+			stats.Missing = append(stats.Missing, result.ObjectID)
+		case result.Err != nil:
+			if ctx.Err() == nil {
+				stats.Failed[result.ObjectID] = result.Err
+			}
+			continue
+		default:
+			if err := h.Sink.Write(ctx, result.Object); err != nil {
+				return stats, fmt.Errorf("failed writing object %d: %w", result.ObjectID, err)
+			}
+			stats.Written++
+		}
+		if err := checkpoint.add(result.ObjectID); err != nil {
+			return stats, err
+		}
+	}
+	return stats, ctx.Err()
+}
+
+// readCheckpoint returns the set of Object IDs recorded in the checkpoint file
+// at path, and the length of the file's complete records. A missing file is an
+// empty checkpoint.
+//
+// Only newline-terminated lines are records. A crash mid-write can leave a
+// final line holding a prefix of an ID, e.g. "12" of "12345", so an
+// unterminated final line is ignored.
+func readCheckpoint(path string) (map[int]bool, int64, error) {
+	done := map[int]bool{}
+	if path == "" {
+		return done, 0, nil
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, 0, nil
+	} else if err != nil {
+		return nil, 0, fmt.Errorf("failed opening checkpoint: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var length int64
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			return done, length, nil
+		} else if err != nil {
+			return nil, 0, fmt.Errorf("failed reading checkpoint: %w", err)
+		}
+		length += int64(len(line))
+		if id, err := strconv.Atoi(strings.TrimSpace(line)); err == nil {
+			done[id] = true
+		}
+	}
+}
+
+// checkpointFile appends completed Object IDs to a checkpoint file. A nil
+// *checkpointFile discards them.
+type checkpointFile struct {
+	f *os.File
+}
+
+// openCheckpoint opens the checkpoint file at path for appending, first
+// truncating it to length, the length of its complete records, so a
+// partially written record is discarded rather than completed.
+func openCheckpoint(path string, length int64) (*checkpointFile, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening checkpoint: %w", err)
+	}
+	if err := f.Truncate(length); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed truncating checkpoint: %w", err)
+	}
+	return &checkpointFile{f: f}, nil
+}
+
+func (c *checkpointFile) add(id int) error {
+	if c == nil {
+		return nil
+	}
+	if _, err := c.f.WriteString(strconv.Itoa(id) + "\n"); err != nil {
+		return fmt.Errorf("failed writing checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (c *checkpointFile) Close() error {
+	if c == nil {
+		return nil
+	}
+	return c.f.Close()
+}
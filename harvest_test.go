@@ -0,0 +1,81 @@
+package met
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHarvesterResumes(t *testing.T) {
+	server := objectServer()
+	defer server.Close()
+	c := newTestClient(t, server)
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint")
+
+	// Interrupt the first harvest after ten records.
+	written := map[int]int{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := &Harvester{
+		Client: c,
+		Sink: SinkFunc(func(ctx context.Context, obj *ObjectResult) error {
+			if written[obj.ObjectID]++; len(written) == 10 {
+				cancel()
+			}
+			return nil
+		}),
+		CheckpointPath: checkpointPath,
+	}
+	if _, err := interrupted.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected cancellation error, got: %v", err)
+	}
+
+	var out bytes.Buffer
+	resumed := &Harvester{
+		Client:         c,
+		Sink:           NewJSONLinesSink(&out),
+		CheckpointPath: checkpointPath,
+	}
+	stats, err := resumed.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.Listed != 45 || stats.Resumed < 10 || len(stats.Failed) != 0 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != stats.Written {
+		t.Errorf("Sink has %d lines for %d written records", lines, stats.Written)
+	}
+	if total := len(written) + stats.Written; total != 43 {
+		t.Errorf("Expected 43 records across both runs, got %d", total)
+	}
+}
+
+func TestCheckpointIgnoresTornRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	// A crash cut off "12345" after its first two digits.
+	if err := os.WriteFile(path, []byte("5\n12"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	done, length, err := readCheckpoint(path)
+	if err != nil || !reflect.DeepEqual(done, map[int]bool{5: true}) || length != 2 {
+		t.Fatalf("Unexpected checkpoint: %v, %d, %v", done, length, err)
+	}
+
+	checkpoint, err := openCheckpoint(path, length)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := checkpoint.add(7); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkpoint.Close()
+	if done, _, _ := readCheckpoint(path); !reflect.DeepEqual(done, map[int]bool{5: true, 7: true}) {
+		t.Errorf("Torn record should not survive reopening: %v", done)
+	}
+}
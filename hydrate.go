@@ -0,0 +1,103 @@
+package met
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultPageSize is the number of objects per page when paging options do not
+// specify a limit.
+const DefaultPageSize = 20
+
+// SearchObjectsOptions encapsulates arguments for SearchObjects.
+type SearchObjectsOptions struct {
+	// SearchOptions is the search query.
+	SearchOptions
+	// Offset is the number of matching objects to skip. It must not be
+	// negative.
+	Offset int
+	// Limit is the maximum number of objects to return. If unspecified,
+	// DefaultPageSize is used.
+	Limit int
+	// Concurrency is the maximum number of concurrent Object requests. If
+	// unspecified, DefaultConcurrency is used.
+	Concurrency int
+}
+
+func (options SearchObjectsOptions) limit() int {
+	if options.Limit > 0 {
+		return options.Limit
+	}
+	return DefaultPageSize
+}
+
+// SearchObjectsResult is a page of hydrated search results.
+type SearchObjectsResult struct {
+	// Total is the total number of objects matching the search.
+	Total int
+	// Offset is the position of the page's first object in the search results.
+	Offset int
+	// Limit is the page size.
+	Limit int
+	// Objects are the records for the page's objects, in search result order.
+	Objects []*ObjectResult
+	// MissingObjectIDs lists IDs in the page for which the Met API returned no
+	// record; search results can include deleted objects.
+	MissingObjectIDs []int
+}
+
+// Page returns the 1-indexed number of this page.
+func (r *SearchObjectsResult) Page() int {
+	return r.Offset/r.Limit + 1
+}
+
+// Pages returns the number of pages of results at this page size.
+func (r *SearchObjectsResult) Pages() int {
+	return (r.Total + r.Limit - 1) / r.Limit
+}
+
+// SearchObjects runs a search and fetches the records for one page of the
+// matching objects. Only the objects in the requested page are fetched. A
+// negative Offset is an error matching ErrInvalidSearch, returned before any
+// request is made.
+func (c *Client) SearchObjects(ctx context.Context, options SearchObjectsOptions) (*SearchObjectsResult, error) {
+	if options.Offset < 0 {
+		return nil, fmt.Errorf("%w: negative offset %d", ErrInvalidSearch, options.Offset)
+	}
+	search, err := c.SearchContext(ctx, options.SearchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchObjectsResult{
+		Total:  len(search.ObjectIDs),
+		Offset: options.Offset,
+		Limit:  options.limit(),
+	}
+	if options.Offset >= len(search.ObjectIDs) {
+		return result, nil
+	}
+	end := options.Offset + result.Limit
+	if end > len(search.ObjectIDs) {
+		end = len(search.ObjectIDs)
+	}
+
+	page, err := c.ObjectsByID(ctx, search.ObjectIDs[options.Offset:end], ObjectsByIDOptions{
+		Concurrency: options.Concurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range page {
+		switch {
+		case errors.Is(p.Err, ErrObjectNotFound):
+			result.MissingObjectIDs = append(result.MissingObjectIDs, p.ObjectID)
+		case p.Err != nil:
+			return nil, fmt.Errorf("failed fetching object %d: %w", p.ObjectID, p.Err)
+		default:
+			result.Objects = append(result.Objects, p.Object)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package met
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSearchObjects(t *testing.T) {
+	server := objectServer()
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	first, err := c.SearchObjects(context.Background(), SearchObjectsOptions{
+		SearchOptions: SearchOptions{Q: "sunflowers"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if first.Total != 45 || first.Page() != 1 || first.Pages() != 3 {
+		t.Errorf("Unexpected paging: total=%d page=%d pages=%d", first.Total, first.Page(), first.Pages())
+	}
+	if len(first.Objects) != 18 || len(first.MissingObjectIDs) != 2 {
+		t.Errorf("Expected 18 objects and 2 missing, got %d and %v", len(first.Objects), first.MissingObjectIDs)
+	}
+
+	last, err := c.SearchObjects(context.Background(), SearchObjectsOptions{
+		SearchOptions: SearchOptions{Q: "sunflowers"},
+		Offset:        40,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if last.Page() != 3 || len(last.Objects) != 5 || last.Objects[0].ObjectID != 39 {
+		t.Errorf("Unexpected last page: page=%d objects=%d", last.Page(), len(last.Objects))
+	}
+	_, err = c.SearchObjects(context.Background(), SearchObjectsOptions{
+		SearchOptions: SearchOptions{Q: "sunflowers"},
+		Offset:        -20,
+	})
+	if !errors.Is(err, ErrInvalidSearch) {
+		t.Errorf("Expected invalid search error for negative offset, got: %v", err)
+	}
+}
@@ -0,0 +1,297 @@
+package met
+
+import (
+	"iter"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Index is a local full-text index over object records, for ranked offline
+// search. Build one from harvested records, the CSV dump, or any iterator of
+// objects:
+//
+//	ix := NewIndex(departments)
+//	if err := ix.AddAll(csvReader.All()); err != nil {
+//	  // Handle error.
+//	}
+//	res := ix.Search(IndexQuery{Text: "wheat field", Facets: []Facet{FacetDepartment}})
+//
+// Index is safe for concurrent use.
+type Index struct {
+	mu sync.RWMutex
+	// docs maps Object IDs to their indexed documents.
+	docs map[int]*indexedDoc
+	// postings maps each term to the weighted frequency of the term in each
+	// document containing it.
+	postings    map[string]map[int]float64
+	totalLength float64
+	departments []Department
+}
+
+type indexedDoc struct {
+	obj    *ObjectResult
+	terms  map[string]float64
+	length float64
+}
+
+// fieldWeights weight matches by the field in which they occur.
+var fieldWeights = []struct {
+	weight float64
+	fields func(*ObjectResult) []string
+}{
+	{3, func(o *ObjectResult) []string { return []string{o.Title} }},
+	{2, func(o *ObjectResult) []string {
+		fields := []string{o.ArtistDisplayName}
+		for _, c := range o.Constituents {
+			if c.Name != o.ArtistDisplayName {
+				fields = append(fields, c.Name)
+			}
+		}
+		return fields
+	}},
+	{1.5, func(o *ObjectResult) []string {
+		fields := []string{o.Culture}
+		for _, tag := range o.Tags {
+			fields = append(fields, tag.Term)
+		}
+		return fields
+	}},
+	{1, func(o *ObjectResult) []string { return []string{o.Medium, o.ObjectName, o.Classification} }},
+	{0.5, func(o *ObjectResult) []string { return []string{o.CreditLine} }},
+}
+
+// BM25 parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// NewIndex returns an empty Index. departments resolves
+// SearchOptions.DepartmentID in query filters.
+func NewIndex(departments []Department) *Index {
+	return &Index{
+		docs:        map[int]*indexedDoc{},
+		postings:    map[string]map[int]float64{},
+		departments: departments,
+	}
+}
+
+// tokenize splits s into lowercase alphanumeric terms.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Add indexes obj, replacing any record with the same Object ID.
+func (ix *Index) Add(obj *ObjectResult) {
+	doc := &indexedDoc{obj: obj, terms: map[string]float64{}}
+	for _, fw := range fieldWeights {
+		for _, field := range fw.fields(obj) {
+			for _, term := range tokenize(field) {
+				doc.terms[term] += fw.weight
+				doc.length += fw.weight
+			}
+		}
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(obj.ObjectID)
+	ix.docs[obj.ObjectID] = doc
+	ix.totalLength += doc.length
+	for term, freq := range doc.terms {
+		if ix.postings[term] == nil {
+			ix.postings[term] = map[int]float64{}
+		}
+		ix.postings[term][obj.ObjectID] = freq
+	}
+}
+
+// AddAll indexes every object yielded by seq, stopping at the first error.
+func (ix *Index) AddAll(seq iter.Seq2[*ObjectResult, error]) error {
+	for obj, err := range seq {
+		if err != nil {
+			return err
+		}
+		ix.Add(obj)
+	}
+	return nil
+}
+
+// Remove removes the object with the specified ID from the index.
+func (ix *Index) Remove(objectID int) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(objectID)
+}
+
+// remove removes a document. ix.mu must be held.
+func (ix *Index) remove(objectID int) {
+	doc, ok := ix.docs[objectID]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		delete(ix.postings[term], objectID)
+		if len(ix.postings[term]) == 0 {
+			delete(ix.postings, term)
+		}
+	}
+	ix.totalLength -= doc.length
+	delete(ix.docs, objectID)
+}
+
+// Len returns the number of indexed objects.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// Facet identifies an ObjectResult field whose values are counted in search
+// results.
+type Facet string
+
+// Supported facets.
+const (
+	FacetDepartment     Facet = "department"
+	FacetClassification Facet = "classification"
+	FacetCulture        Facet = "culture"
+	FacetCountry        Facet = "country"
+	FacetArtist         Facet = "artist"
+	FacetObjectName     Facet = "objectName"
+)
+
+// value returns obj's value for the facet.
+func (f Facet) value(obj *ObjectResult) string {
+	switch f {
+	case FacetDepartment:
+		return obj.Department
+	case FacetClassification:
+		return obj.Classification
+	case FacetCulture:
+		return obj.Culture
+	case FacetCountry:
+		return obj.Country
+	case FacetArtist:
+		return obj.ArtistDisplayName
+	case FacetObjectName:
+		return obj.ObjectName
+	}
+	return ""
+}
+
+// IndexQuery encapsulates arguments for Index.Search.
+type IndexQuery struct {
+	// Text is a full-text query. Results contain every term in Text, ranked by
+	// relevance; matches in titles and artist names rank highest. If Text is
+	// empty, every object passing Filter matches, in Object ID order.
+	Text string
+	// Filter restricts results like the corresponding Met API search
+	// parameters. Its Q is ignored; ArtistOrCulture has no effect.
+	Filter SearchOptions
+	// Facets lists the fields whose values are counted across all results.
+	Facets []Facet
+	// Offset is the number of ranked results to skip.
+	Offset int
+	// Limit is the maximum number of results to return. If unspecified,
+	// DefaultPageSize is used.
+	Limit int
+}
+
+// IndexHit is a ranked search result.
+type IndexHit struct {
+	Object *ObjectResult
+	Score  float64
+}
+
+// IndexResult is a page of ranked search results.
+type IndexResult struct {
+	// Total is the number of matching objects.
+	Total int
+	// Hits are the page of matching objects, in rank order.
+	Hits []IndexHit
+	// Facets maps each requested facet to counts of its non-empty values
+	// across all matching objects.
+	Facets map[Facet]map[string]int
+}
+
+// Search runs a query against the index.
+func (ix *Index) Search(q IndexQuery) *IndexResult {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	filter := q.Filter
+	filter.Q = ""
+	filter.ArtistOrCulture = nil
+	departmentName := departmentNamer(ix.departments)
+
+	var hits []IndexHit
+	for id, score := range ix.score(tokenize(q.Text)) {
+		obj := ix.docs[id].obj
+		if filter.matches(obj, departmentName) {
+			hits = append(hits, IndexHit{Object: obj, Score: score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Object.ObjectID < hits[j].Object.ObjectID
+	})
+
+	result := &IndexResult{Total: len(hits)}
+	if len(q.Facets) > 0 {
+		result.Facets = make(map[Facet]map[string]int, len(q.Facets))
+		for _, f := range q.Facets {
+			counts := map[string]int{}
+			for _, hit := range hits {
+				if v := f.value(hit.Object); v != "" {
+					counts[v]++
+				}
+			}
+			result.Facets[f] = counts
+		}
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if q.Offset >= 0 && q.Offset < len(hits) {
+		result.Hits = hits[q.Offset:min(q.Offset+limit, len(hits))]
+	}
+	return result
+}
+
+// score returns the BM25 score of each document containing every term. With
+// no terms, every document scores zero. ix.mu must be held.
+func (ix *Index) score(terms []string) map[int]float64 {
+	scores := map[int]float64{}
+	if len(terms) == 0 {
+		for id := range ix.docs {
+			scores[id] = 0
+		}
+		return scores
+	}
+	n := float64(len(ix.docs))
+	avgLength := ix.totalLength / n
+	for i, term := range terms {
+		postings := ix.postings[term]
+		idf := math.Log(1 + (n-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+		next := map[int]float64{}
+		for id, freq := range postings {
+			prev, ok := scores[id]
+			if i > 0 && !ok {
+				continue
+			}
+			norm := bm25K1 * (1 - bm25B + bm25B*ix.docs[id].length/avgLength)
+			next[id] = prev + idf*freq*(bm25K1+1)/(freq+norm)
+		}
+		scores = next
+	}
+	return scores
+}
@@ -0,0 +1,85 @@
+package met
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func testIndex() *Index {
+	ix := NewIndex(testDepartments)
+	for _, obj := range []*ObjectResult{
+		{ObjectID: 1, Title: "Sunflowers", ArtistDisplayName: "Vincent van Gogh", Department: "European Paintings", Classification: "Paintings", Country: "France", GalleryNumber: "825"},
+		{ObjectID: 2, Title: "Wheat Field with Cypresses", ArtistDisplayName: "Vincent van Gogh", Department: "European Paintings", Classification: "Paintings", Tags: []Tag{{Term: "Sunflowers"}}},
+		{ObjectID: 3, Title: "Quilt", Medium: "Cotton printed with sunflowers", Department: "American Decorative Arts", Classification: "Textiles", ObjectBeginDate: 1830, ObjectEndDate: 1840},
+		{ObjectID: 4, Title: "Roses", ArtistDisplayName: "Henri Fantin-Latour", Department: "European Paintings", Classification: "Paintings"},
+	} {
+		ix.Add(obj)
+	}
+	return ix
+}
+
+func hitIDs(res *IndexResult) []int {
+	var ids []int
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.Object.ObjectID)
+	}
+	return ids
+}
+
+// sameIDs reports whether a and b contain the same IDs in any order.
+func sameIDs(a, b []int) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func TestIndexRanking(t *testing.T) {
+	ix := testIndex()
+	res := ix.Search(IndexQuery{Text: "Sunflowers"})
+	// The title match outranks the tag and medium matches.
+	if ids := hitIDs(res); res.Total != 3 || ids[0] != 1 || !sameIDs(ids[1:], []int{2, 3}) {
+		t.Errorf("Unexpected ranking: %v", ids)
+	}
+	res = ix.Search(IndexQuery{Text: "van gogh sunflowers"})
+	if !reflect.DeepEqual(hitIDs(res), []int{1, 2}) {
+		t.Errorf("Every query term should match: %v", hitIDs(res))
+	}
+}
+
+func TestIndexFiltersAndFacets(t *testing.T) {
+	ix := testIndex()
+	res := ix.Search(IndexQuery{
+		Text:   "sunflowers",
+		Filter: SearchOptions{DepartmentID: 11, IsOnView: false},
+	})
+	if !reflect.DeepEqual(hitIDs(res), []int{2}) {
+		t.Errorf("Unexpected filtered hits: %v", hitIDs(res))
+	}
+	res = ix.Search(IndexQuery{Filter: SearchOptions{YearRange: NewYearRange(1800, 1850)}})
+	if !reflect.DeepEqual(hitIDs(res), []int{3}) {
+		t.Errorf("Unexpected year-range hits: %v", hitIDs(res))
+	}
+
+	res = ix.Search(IndexQuery{Facets: []Facet{FacetDepartment, FacetClassification}, Limit: 1})
+	expected := map[Facet]map[string]int{
+		FacetDepartment:     {"European Paintings": 3, "American Decorative Arts": 1},
+		FacetClassification: {"Paintings": 3, "Textiles": 1},
+	}
+	if !reflect.DeepEqual(res.Facets, expected) || res.Total != 4 || len(res.Hits) != 1 {
+		t.Errorf("Unexpected facets %v for %d hits", res.Facets, res.Total)
+	}
+}
+
+func TestIndexReplaceAndRemove(t *testing.T) {
+	ix := testIndex()
+	ix.Add(&ObjectResult{ObjectID: 1, Title: "Irises"})
+	if res := ix.Search(IndexQuery{Text: "sunflowers"}); !sameIDs(hitIDs(res), []int{2, 3}) {
+		t.Errorf("Replaced record should not match old terms: %v", hitIDs(res))
+	}
+	ix.Remove(1)
+	if res := ix.Search(IndexQuery{Text: "irises"}); res.Total != 0 || ix.Len() != 3 {
+		t.Errorf("Removed record should not match: %v", hitIDs(res))
+	}
+}
@@ -0,0 +1,67 @@
+package met
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// ObjectsSeq returns an iterator over the records for the objects listed in
+// result. Each object is fetched when the iteration reaches it, so breaking
+// out of a range loop stops further requests.
+//
+// A failure fetching an object yields a nil record and an error identifying
+// the object; iteration continues if the loop does. Once ctx is done,
+// iteration stops after yielding a single error matching ctx's error.
+func (c *Client) ObjectsSeq(ctx context.Context, result *ObjectsResult) iter.Seq2[*ObjectResult, error] {
+	return func(yield func(*ObjectResult, error) bool) {
+		for _, id := range result.ObjectIDs {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			obj, err := c.ObjectContext(ctx, ObjectOptions{ObjectID: id})
+			if err != nil {
+				err = fmt.Errorf("failed fetching object %d: %w", id, err)
+			}
+			if !yield(obj, err) || (err != nil && ctx.Err() != nil) {
+				return
+			}
+		}
+	}
+}
+
+// AllObjects returns an iterator over the records for every object listed by
+// Objects with the specified options. See ObjectsSeq.
+//
+//	for obj, err := range c.AllObjects(ctx, ObjectsOptions{DepartmentIDs: []int{1}}) {
+//	  if err != nil {
+//	    // Handle error.
+//	  }
+//	}
+func (c *Client) AllObjects(ctx context.Context, options ObjectsOptions) iter.Seq2[*ObjectResult, error] {
+	return c.listingSeq(ctx, func() (*ObjectsResult, error) {
+		return c.ObjectsContext(ctx, options)
+	})
+}
+
+// AllSearchObjects returns an iterator over the records for every object
+// matching a search with the specified options. See ObjectsSeq.
+func (c *Client) AllSearchObjects(ctx context.Context, options SearchOptions) iter.Seq2[*ObjectResult, error] {
+	return c.listingSeq(ctx, func() (*ObjectsResult, error) {
+		return c.SearchContext(ctx, options)
+	})
+}
+
+// listingSeq returns an iterator that calls list when iteration begins, then
+// walks the listed objects. If list fails, the iterator yields its error alone.
+func (c *Client) listingSeq(ctx context.Context, list func() (*ObjectsResult, error)) iter.Seq2[*ObjectResult, error] {
+	return func(yield func(*ObjectResult, error) bool) {
+		result, err := list()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		c.ObjectsSeq(ctx, result)(yield)
+	}
+}
@@ -0,0 +1,75 @@
+package met
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAllSearchObjectsStopsOnBreak(t *testing.T) {
+	var objectRequests atomic.Int32
+	inner := objectServer()
+	defer inner.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/objects/") {
+			objectRequests.Add(1)
+		}
+		inner.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	var missing, found int
+	for obj, err := range c.AllSearchObjects(context.Background(), SearchOptions{Q: "sunflowers"}) {
+		if errors.Is(err, ErrObjectNotFound) {
+			missing++
+			continue
+		} else if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if found++; found == 3 || obj == nil {
+			break
+		}
+	}
+	if missing != 2 || found != 3 {
+		t.Errorf("Expected 2 missing and 3 found objects, got %d and %d", missing, found)
+	}
+	if n := objectRequests.Load(); n != 5 {
+		t.Errorf("Expected iteration to stop after 5 requests, got %d", n)
+	}
+}
+
+func TestObjectsSeqStopsOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/objects/2" {
+			// Cancel while the fetch is in flight.
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"objectID": 1}`))
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	var found int
+	var errs []error
+	for obj, err := range c.ObjectsSeq(ctx, &ObjectsResult{ObjectIDs: []int{1, 2, 3}}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if obj != nil {
+			found++
+		}
+	}
+	if found != 1 || len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("Expected 1 object and a single cancellation error, got %d and %v", found, errs)
+	}
+}
@@ -1,31 +1,34 @@
-package met
+package met_test
 
 import (
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
+
+	"github.com/lukasschwab/met"
+	"github.com/lukasschwab/met/mettest"
 )
 
 func TestCustomClient(t *testing.T) {
-	cli := NewClient(&http.Client{
+	cli := met.NewClient(&http.Client{
 		// Too short to reasonably succeed.
 		Timeout: 1 * time.Nanosecond,
 	})
-	_, err := cli.Objects(ObjectsOptions{})
+	_, err := cli.Objects(met.ObjectsOptions{})
 	if err == nil {
 		t.Errorf("Custom client with infinitessimal timeout should always error.")
 	}
 }
 
 func TestObjects(t *testing.T) {
-	c := NewClient(&http.Client{})
-	all, _ := c.Objects(ObjectsOptions{})
+	c := newFakeClient(t)
+	all, _ := c.Objects(met.ObjectsOptions{})
 	checkObjectsLengthsAgree(t, all)
 
-	yesterday := time.Now().AddDate(0, 0, -1)
-	recent, _ := c.Objects(ObjectsOptions{
-		MetadataDate: &yesterday,
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent, _ := c.Objects(met.ObjectsOptions{
+		MetadataDate: &since,
 	})
 	checkObjectsLengthsAgree(t, recent)
 
@@ -33,16 +36,19 @@ func TestObjects(t *testing.T) {
 		t.Errorf("New (%d) should be fewer objects than All (%d).", recent.Total, all.Total)
 	}
 
-	deps, _ := c.Objects(ObjectsOptions{
+	deps, _ := c.Objects(met.ObjectsOptions{
 		DepartmentIDs: []int{1},
 	})
 	checkObjectsLengthsAgree(t, deps)
 }
 
+// The examples below make live requests, so they have no output comments: go
+// test compiles them but does not run them.
+
 func ExampleClient_Objects_all() {
-	c := NewClient(&http.Client{})
+	c := met.NewClient(&http.Client{})
 	// Get all objects.
-	allObjects, err := c.Objects(ObjectsOptions{})
+	allObjects, err := c.Objects(met.ObjectsOptions{})
 	if err != nil {
 		// Handle error.
 	}
@@ -50,10 +56,10 @@ func ExampleClient_Objects_all() {
 }
 
 func ExampleClient_Objects_date() {
-	c := NewClient(&http.Client{})
+	c := met.NewClient(&http.Client{})
 	// Get all objects updated in the last 20 years.
 	twentyYearsAgo := time.Now().AddDate(-20, 0, 0)
-	recentObjects, err := c.Objects(ObjectsOptions{
+	recentObjects, err := c.Objects(met.ObjectsOptions{
 		MetadataDate: &twentyYearsAgo,
 	})
 	if err != nil {
@@ -63,10 +69,10 @@ func ExampleClient_Objects_date() {
 }
 
 func ExampleClient_Objects_department() {
-	c := NewClient(&http.Client{})
+	c := met.NewClient(&http.Client{})
 	// Get all objects updated in the last 20 years in Department 1.
 	twentyYearsAgo := time.Now().AddDate(-20, 0, 0)
-	d1Objects, err := c.Objects(ObjectsOptions{
+	d1Objects, err := c.Objects(met.ObjectsOptions{
 		MetadataDate:  &twentyYearsAgo,
 		DepartmentIDs: []int{1},
 	})
@@ -77,24 +83,24 @@ func ExampleClient_Objects_department() {
 }
 
 func TestObject(t *testing.T) {
-	c := NewClient(&http.Client{})
+	c := newFakeClient(t)
 	targetObject := 436535
-	o, err := c.Object(ObjectOptions{ObjectID: targetObject})
+	o, err := c.Object(met.ObjectOptions{ObjectID: targetObject})
 	if err != nil {
 		t.Errorf("Valid fetch got error: %s", err)
 	} else if o.ObjectID != targetObject {
 		t.Errorf("Object ID does not match target.")
 	}
 
-	_, err = c.Object(ObjectOptions{ObjectID: -1})
+	_, err = c.Object(met.ObjectOptions{ObjectID: -1})
 	if err == nil {
 		t.Errorf("Invalid ID should produce 404 status error.")
 	}
 }
 
 func ExampleClient_Object() {
-	c := NewClient(&http.Client{})
-	obj, err := c.Object(ObjectOptions{ObjectID: 436535})
+	c := met.NewClient(&http.Client{})
+	obj, err := c.Object(met.ObjectOptions{ObjectID: 436535})
 	if err != nil {
 		// Handle error.
 	}
@@ -102,7 +108,7 @@ func ExampleClient_Object() {
 }
 
 func TestDepartments(t *testing.T) {
-	c := NewClient(&http.Client{})
+	c := newFakeClient(t)
 	o, err := c.Departments()
 	if err != nil {
 		t.Errorf("Valid fetch got error: %s", err)
@@ -114,7 +120,7 @@ func TestDepartments(t *testing.T) {
 }
 
 func ExampleClient_Departments() {
-	c := NewClient(&http.Client{})
+	c := met.NewClient(&http.Client{})
 	depts, err := c.Departments()
 	if err != nil {
 		// Handle error.
@@ -123,13 +129,13 @@ func ExampleClient_Departments() {
 }
 
 func TestSearch(t *testing.T) {
-	c := NewClient(&http.Client{})
-	o, err := c.Search(SearchOptions{Q: "sunflowers"})
+	c := newFakeClient(t)
+	o, err := c.Search(met.SearchOptions{Q: "sunflowers"})
 	if err != nil {
 		t.Errorf("Valid fetch got error: %s", err)
 	}
 	checkObjectsLengthsAgree(t, o)
-	o, err = c.Search(SearchOptions{Q: "sunflowers", IsHighlight: true})
+	o, err = c.Search(met.SearchOptions{Q: "sunflowers", IsHighlight: true})
 	if err != nil {
 		t.Errorf("Valid fetch got error: %s", err)
 	}
@@ -137,8 +143,8 @@ func TestSearch(t *testing.T) {
 }
 
 func ExampleClient_Search_query() {
-	c := NewClient(&http.Client{})
-	results, err := c.Search(SearchOptions{Q: "sunflower"})
+	c := met.NewClient(&http.Client{})
+	results, err := c.Search(met.SearchOptions{Q: "sunflower"})
 	if err != nil {
 		// Handle error.
 	}
@@ -146,8 +152,8 @@ func ExampleClient_Search_query() {
 }
 
 func ExampleClient_Search_highlights() {
-	c := NewClient(&http.Client{})
-	results, err := c.Search(SearchOptions{
+	c := met.NewClient(&http.Client{})
+	results, err := c.Search(met.SearchOptions{
 		Q:           "sunflower",
 		IsHighlight: true,
 	})
@@ -158,8 +164,8 @@ func ExampleClient_Search_highlights() {
 }
 
 func ExampleClient_Search_department() {
-	c := NewClient(&http.Client{})
-	results, err := c.Search(SearchOptions{
+	c := met.NewClient(&http.Client{})
+	results, err := c.Search(met.SearchOptions{
 		Q:            "cat",
 		DepartmentID: 6,
 	})
@@ -170,8 +176,8 @@ func ExampleClient_Search_department() {
 }
 
 func ExampleClient_Search_view() {
-	c := NewClient(&http.Client{})
-	results, err := c.Search(SearchOptions{
+	c := met.NewClient(&http.Client{})
+	results, err := c.Search(met.SearchOptions{
 		Q:        "sunflower",
 		IsOnView: true,
 	})
@@ -182,8 +188,8 @@ func ExampleClient_Search_view() {
 }
 
 func ExampleClient_Search_culture() {
-	c := NewClient(&http.Client{})
-	results, err := c.Search(SearchOptions{
+	c := met.NewClient(&http.Client{})
+	results, err := c.Search(met.SearchOptions{
 		Q:               "french",
 		ArtistOrCulture: true,
 	})
@@ -194,8 +200,8 @@ func ExampleClient_Search_culture() {
 }
 
 func ExampleClient_Search_media() {
-	c := NewClient(&http.Client{})
-	results, err := c.Search(SearchOptions{
+	c := met.NewClient(&http.Client{})
+	results, err := c.Search(met.SearchOptions{
 		Q:     "quilt",
 		Media: []string{"Quilts", "Silk", "Bedcovers"},
 	})
@@ -206,8 +212,8 @@ func ExampleClient_Search_media() {
 }
 
 func ExampleClient_Search_images() {
-	c := NewClient(&http.Client{})
-	results, err := c.Search(SearchOptions{
+	c := met.NewClient(&http.Client{})
+	results, err := c.Search(met.SearchOptions{
 		Q:         "Auguste Renoir",
 		HasImages: true,
 	})
@@ -218,8 +224,8 @@ func ExampleClient_Search_images() {
 }
 
 func ExampleClient_Search_geolocation() {
-	c := NewClient(&http.Client{})
-	results, err := c.Search(SearchOptions{
+	c := met.NewClient(&http.Client{})
+	results, err := c.Search(met.SearchOptions{
 		Q:            "flowers",
 		GeoLocations: []string{"France"},
 	})
@@ -230,10 +236,10 @@ func ExampleClient_Search_geolocation() {
 }
 
 func ExampleClient_Search_dates() {
-	c := NewClient(&http.Client{})
-	results, err := c.Search(SearchOptions{
+	c := met.NewClient(&http.Client{})
+	results, err := c.Search(met.SearchOptions{
 		Q:         "African",
-		YearRange: NewYearRange(1700, 1800),
+		YearRange: met.NewYearRange(1700, 1800),
 	})
 	if err != nil {
 		// Handle error.
@@ -243,7 +249,17 @@ func ExampleClient_Search_dates() {
 
 // Utilities.
 
-func checkObjectsLengthsAgree(t *testing.T, o *ObjectsResult) {
+// newFakeClient returns a met.Client for a mettest.Server serving
+// mettest.DefaultFixtures, closed when the test finishes. The fake approximates
+// the Met API offline; see package mettest.
+func newFakeClient(t *testing.T) *met.Client {
+	t.Helper()
+	srv := mettest.NewServer(mettest.DefaultFixtures())
+	t.Cleanup(srv.Close)
+	return srv.MetClient()
+}
+
+func checkObjectsLengthsAgree(t *testing.T, o *met.ObjectsResult) {
 	if o.Total != len(o.ObjectIDs) {
 		t.Errorf("Lengths don't match: Total=%d, Length=%d", o.Total, len(o.ObjectIDs))
 	}
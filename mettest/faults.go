@@ -0,0 +1,193 @@
+package mettest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Faults configures the failures injected by a FaultTransport. Rates are
+// probabilities in [0, 1] that a request suffers each fault; at most one fault
+// other than latency is injected per request.
+type Faults struct {
+	// Latency delays every request.
+	Latency time.Duration
+	// ServerErrorRate is the rate of 503 Service Unavailable responses.
+	ServerErrorRate float64
+	// RateLimitRate is the rate of 429 Too Many Requests responses.
+	RateLimitRate float64
+	// RetryAfter, if positive, is sent as the Retry-After header of 429
+	// responses, rounded up to whole seconds.
+	RetryAfter time.Duration
+	// TruncateRate is the rate of responses whose body is cut off halfway.
+	TruncateRate float64
+	// ResetRate is the rate of requests failing with a connection reset.
+	ResetRate float64
+}
+
+// FaultCounts tallies the faults injected by a FaultTransport.
+type FaultCounts struct {
+	Requests     int
+	ServerErrors int
+	RateLimits   int
+	Truncations  int
+	Resets       int
+}
+
+// FaultTransport is an http.RoundTripper that injects failures into requests
+// made through an underlying transport, for testing how code using met.Client
+// copes with an unreliable Met API:
+//
+//	ft := mettest.NewFaultTransport(nil, mettest.Faults{ServerErrorRate: 0.1}, 1)
+//	c := met.NewClient(ft.Client())
+type FaultTransport struct {
+	transport http.RoundTripper
+	faults    Faults
+
+	mu     sync.Mutex
+	rand   *rand.Rand
+	counts FaultCounts
+}
+
+// NewFaultTransport returns a FaultTransport injecting faults into requests
+// made with transport, or http.DefaultTransport if transport is nil. Faults are
+// chosen pseudo-randomly from seed, so the sequence of faults is reproducible.
+// With concurrent callers, which request suffers which fault depends on
+// scheduling.
+func NewFaultTransport(transport http.RoundTripper, faults Faults, seed int64) *FaultTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &FaultTransport{
+		transport: transport,
+		faults:    faults,
+		rand:      rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Client returns an *http.Client using the FaultTransport.
+func (t *FaultTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// Counts returns the faults injected so far.
+func (t *FaultTransport) Counts() FaultCounts {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.counts
+}
+
+// fault identifies the failure injected into a request.
+type fault int
+
+const (
+	noFault fault = iota
+	resetFault
+	serverErrorFault
+	rateLimitFault
+	truncateFault
+)
+
+// choose picks the fault for a request and records it.
+func (t *FaultTransport) choose() fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.counts.Requests++
+	r := t.rand.Float64()
+	for _, f := range []struct {
+		fault fault
+		rate  float64
+		count *int
+	}{
+		{resetFault, t.faults.ResetRate, &t.counts.Resets},
+		{serverErrorFault, t.faults.ServerErrorRate, &t.counts.ServerErrors},
+		{rateLimitFault, t.faults.RateLimitRate, &t.counts.RateLimits},
+		{truncateFault, t.faults.TruncateRate, &t.counts.Truncations},
+	} {
+		if r < f.rate {
+			*f.count++
+			return f.fault
+		}
+		r -= f.rate
+	}
+	return noFault
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.faults.Latency > 0 {
+		timer := time.NewTimer(t.faults.Latency)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	switch t.choose() {
+	case resetFault:
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	case serverErrorFault:
+		return syntheticResponse(req, http.StatusServiceUnavailable, nil), nil
+	case rateLimitFault:
+		header := http.Header{}
+		if t.faults.RetryAfter > 0 {
+			header.Set("Retry-After", strconv.Itoa(int(math.Ceil(t.faults.RetryAfter.Seconds()))))
+		}
+		return syntheticResponse(req, http.StatusTooManyRequests, header), nil
+	case truncateFault:
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// Fail like a connection closed mid-body.
+		resp.Body = io.NopCloser(io.MultiReader(
+			bytes.NewReader(body[:len(body)/2]),
+			errReader{io.ErrUnexpectedEOF},
+		))
+		return resp, nil
+	}
+	return t.transport.RoundTrip(req)
+}
+
+func syntheticResponse(req *http.Request, status int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	body := fmt.Sprintf(`{"message": %q}`, http.StatusText(status))
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// errReader is an io.Reader that always fails with err.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
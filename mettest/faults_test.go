@@ -0,0 +1,71 @@
+package mettest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lukasschwab/met"
+)
+
+func TestFaultTransport(t *testing.T) {
+	srv := NewServer(DefaultFixtures())
+	defer srv.Close()
+	ft := NewFaultTransport(srv.Client().Transport, Faults{
+		Latency:         time.Millisecond,
+		ServerErrorRate: 0.1,
+		RateLimitRate:   0.1,
+		TruncateRate:    0.1,
+		ResetRate:       0.1,
+	}, 1)
+	c := met.NewClient(ft.Client())
+	c.RootURL = srv.RootURL()
+	c.DisableCoalescing = true
+	c.Retry = met.DefaultRetryPolicy()
+	c.Retry.MaxAttempts = 10
+	c.Retry.InitialBackoff = time.Millisecond
+	c.Retry.MaxBackoff = time.Millisecond
+
+	var ids []int
+	for i := 0; i < 10; i++ {
+		for _, obj := range DefaultFixtures().Objects {
+			ids = append(ids, obj.ObjectID)
+		}
+	}
+	results, err := c.ObjectsByID(context.Background(), ids, met.ObjectsByIDOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			t.Errorf("Object %d failed despite retries: %v", result.ObjectID, result.Err)
+		}
+	}
+
+	counts := ft.Counts()
+	if counts.ServerErrors == 0 || counts.RateLimits == 0 || counts.Truncations == 0 || counts.Resets == 0 {
+		t.Errorf("Expected every kind of fault to be injected: %+v", counts)
+	}
+	faults := counts.ServerErrors + counts.RateLimits + counts.Truncations + counts.Resets
+	if counts.Requests != len(ids)+faults {
+		t.Errorf("Expected one retry per fault: %+v", counts)
+	}
+}
+
+func TestFaultTransportRoundsRetryAfterUp(t *testing.T) {
+	srv := NewServer(DefaultFixtures())
+	defer srv.Close()
+	ft := NewFaultTransport(srv.Client().Transport, Faults{
+		RateLimitRate: 1,
+		RetryAfter:    200 * time.Millisecond,
+	}, 1)
+	resp, err := ft.Client().Get(srv.URL + "/departments")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if got := resp.Header.Get("Retry-After"); resp.StatusCode != http.StatusTooManyRequests || got != "1" {
+		t.Errorf("Expected 429 with Retry-After 1, got %d with %q", resp.StatusCode, got)
+	}
+}
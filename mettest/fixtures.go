@@ -0,0 +1,133 @@
+package mettest
+
+import "github.com/lukasschwab/met"
+
+// DefaultFixtures returns the Met's departments and a small set of
+// representative objects. The object records are abridged; they are shaped
+// like Met API responses but are not verbatim copies of them.
+func DefaultFixtures() Fixtures {
+	return Fixtures{
+		Departments: met.MetDepartments(),
+		Objects: []met.ObjectResult{
+			{
+				ObjectID:          436535,
+				IsHighlight:       true,
+				AccessionNumber:   "49.30",
+				IsPublicDomain:    true,
+				PrimaryImage:      "https://images.metmuseum.org/CRDImages/ep/original/DT1567.jpg",
+				Department:        "European Paintings",
+				ObjectName:        "Painting",
+				Title:             "Wheat Field with Cypresses",
+				ArtistDisplayName: "Vincent van Gogh",
+				ArtistNationality: "Dutch",
+				Constituents:      []met.Constituent{{Name: "Vincent van Gogh", Role: "Artist"}},
+				ObjectDate:        "1889",
+				ObjectBeginDate:   1889,
+				ObjectEndDate:     1889,
+				Medium:            "Oil on canvas",
+				Country:           "France",
+				Classification:    "Paintings",
+				MetadataDate:      "2023-06-01T04:52:03.27Z",
+				Tags:              []met.Tag{{Term: "Landscapes"}, {Term: "Cypresses"}, {Term: "Wheat"}},
+				GalleryNumber:     "822",
+			},
+			{
+				ObjectID:          436524,
+				IsPublicDomain:    true,
+				PrimaryImage:      "https://images.metmuseum.org/CRDImages/ep/original/DP229743.jpg",
+				Department:        "European Paintings",
+				ObjectName:        "Painting",
+				Title:             "Sunflowers",
+				ArtistDisplayName: "Vincent van Gogh",
+				ArtistNationality: "Dutch",
+				Constituents:      []met.Constituent{{Name: "Vincent van Gogh", Role: "Artist"}},
+				ObjectDate:        "1887",
+				ObjectBeginDate:   1887,
+				ObjectEndDate:     1887,
+				Medium:            "Oil on canvas",
+				Country:           "France",
+				City:              "Paris",
+				Classification:    "Paintings",
+				MetadataDate:      "2023-06-01T04:52:03.27Z",
+				Tags:              []met.Tag{{Term: "Sunflowers"}},
+				GalleryNumber:     "825",
+			},
+			{
+				ObjectID:        544442,
+				IsHighlight:     true,
+				IsPublicDomain:  true,
+				PrimaryImage:    "https://images.metmuseum.org/CRDImages/eg/original/DP248993.jpg",
+				Department:      "Egyptian Art",
+				ObjectName:      "Hippopotamus",
+				Title:           "Hippopotamus (\"William\")",
+				Period:          "Middle Kingdom",
+				Dynasty:         "Dynasty 12",
+				ObjectDate:      "ca. 1961–1878 B.C.",
+				ObjectBeginDate: -1961,
+				ObjectEndDate:   -1878,
+				Medium:          "Faience",
+				Country:         "Egypt",
+				Region:          "Middle Egypt",
+				Classification:  "Faience-Sculpture",
+				MetadataDate:    "2023-02-07T04:50:17.913Z",
+				Tags:            []met.Tag{{Term: "Hippopotamuses"}},
+				GalleryNumber:   "111",
+			},
+			{
+				ObjectID:          45734,
+				IsPublicDomain:    true,
+				PrimaryImage:      "https://images.metmuseum.org/CRDImages/as/original/DP251139.jpg",
+				Department:        "Asian Art",
+				ObjectName:        "Hanging scroll",
+				Title:             "Quail and Millet",
+				Culture:           "Japan",
+				Period:            "Edo period (1615–1868)",
+				ArtistDisplayName: "Kiyohara Yukinobu",
+				ArtistNationality: "Japanese",
+				Constituents:      []met.Constituent{{Name: "Kiyohara Yukinobu", Role: "Artist", Gender: "Female"}},
+				ObjectDate:        "late 17th century",
+				ObjectBeginDate:   1667,
+				ObjectEndDate:     1682,
+				Medium:            "Hanging scroll; ink and color on silk",
+				Classification:    "Paintings",
+				MetadataDate:      "2022-12-13T04:51:08.663Z",
+				Tags:              []met.Tag{{Term: "Birds"}, {Term: "Flowers"}},
+			},
+			{
+				ObjectID:        12706,
+				Department:      "American Decorative Arts",
+				ObjectName:      "Quilt",
+				Title:           "Star of Bethlehem Quilt",
+				Culture:         "American",
+				ObjectDate:      "ca. 1830",
+				ObjectBeginDate: 1825,
+				ObjectEndDate:   1835,
+				Medium:          "Cotton",
+				Country:         "United States",
+				State:           "New York",
+				Classification:  "Textiles",
+				MetadataDate:    "2021-05-17T04:50:34.873Z",
+				Tags:            []met.Tag{{Term: "Stars"}},
+			},
+			{
+				ObjectID:          337066,
+				IsPublicDomain:    true,
+				PrimaryImage:      "https://images.metmuseum.org/CRDImages/dp/original/DP818302.jpg",
+				Department:        "Drawings and Prints",
+				ObjectName:        "Print",
+				Title:             "Sunflowers and Butterflies",
+				Culture:           "Japan",
+				ArtistDisplayName: "Katsushika Hokusai",
+				ArtistNationality: "Japanese",
+				Constituents:      []met.Constituent{{Name: "Katsushika Hokusai", Role: "Artist"}},
+				ObjectDate:        "ca. 1831–32",
+				ObjectBeginDate:   1831,
+				ObjectEndDate:     1832,
+				Medium:            "Woodblock print; ink and color on paper",
+				Classification:    "Prints",
+				MetadataDate:      "2024-01-10T04:51:14.487Z",
+				Tags:              []met.Tag{{Term: "Sunflowers"}, {Term: "Butterflies"}},
+			},
+		},
+	}
+}
@@ -0,0 +1,154 @@
+// Package mettest provides an offline fake of the Met API for testing code
+// that uses met.Client.
+//
+//	srv := mettest.NewServer(mettest.DefaultFixtures())
+//	defer srv.Close()
+//	c := srv.MetClient()
+//	res, err := c.Search(met.SearchOptions{Q: "sunflowers"})
+package mettest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/lukasschwab/met"
+)
+
+// Fixtures are the records served by a Server.
+type Fixtures struct {
+	// Objects are the served object records.
+	Objects []met.ObjectResult
+	// Departments are the served departments. Objects are associated with
+	// departments by name, as in ObjectResult.Department.
+	Departments []met.Department
+}
+
+// Server is a fake Met API serving /objects, /objects/{id}, /departments, and
+// /search from in-memory fixtures. It honors the query parameters set by
+// met.ObjectsOptions and met.SearchOptions, answering queries with a
+// met.MemorySource.
+type Server struct {
+	*httptest.Server
+
+	source   *met.MemorySource
+	requests int64
+}
+
+// NewServer starts and returns a Server serving fixtures. The caller should
+// call Close when finished, to shut it down.
+func NewServer(fixtures Fixtures) *Server {
+	objects := make([]*met.ObjectResult, len(fixtures.Objects))
+	for i := range fixtures.Objects {
+		obj := fixtures.Objects[i]
+		objects[i] = &obj
+	}
+	s := &Server{source: met.NewMemorySource(objects, fixtures.Departments)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /objects", s.handleObjects)
+	mux.HandleFunc("GET /objects/{id}", s.handleObject)
+	mux.HandleFunc("GET /departments", s.handleDepartments)
+	mux.HandleFunc("GET /search", s.handleSearch)
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&s.requests, 1)
+		mux.ServeHTTP(w, r)
+	}))
+	return s
+}
+
+// RootURL returns the URL to use as met.Client.RootURL.
+func (s *Server) RootURL() *url.URL {
+	u, _ := url.Parse(s.URL + "/")
+	return u
+}
+
+// MetClient returns a met.Client for the Server.
+func (s *Server) MetClient() *met.Client {
+	c := met.NewClient(s.Client())
+	c.RootURL = s.RootURL()
+	return c
+}
+
+// Requests returns the number of requests the Server has received.
+func (s *Server) Requests() int {
+	return int(atomic.LoadInt64(&s.requests))
+}
+
+// Put adds obj to the served objects, replacing any object with the same ID.
+func (s *Server) Put(obj met.ObjectResult) {
+	s.source.Put(&obj)
+}
+
+// Delete removes the object with the specified ID from the served objects.
+func (s *Server) Delete(objectID int) {
+	s.source.Delete(objectID)
+}
+
+func (s *Server) handleObjects(w http.ResponseWriter, r *http.Request) {
+	options, err := parseObjectsOptions(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	res, err := s.source.ObjectsContext(r.Context(), options)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, res)
+}
+
+func (s *Server) handleObject(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "ObjectID not found")
+		return
+	}
+	obj, err := s.source.ObjectContext(r.Context(), met.ObjectOptions{ObjectID: id})
+	if errors.Is(err, met.ErrObjectNotFound) {
+		writeError(w, http.StatusNotFound, "ObjectID not found")
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, obj)
+}
+
+func (s *Server) handleDepartments(w http.ResponseWriter, r *http.Request) {
+	res, err := s.source.DepartmentsContext(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, res)
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	options, err := parseSearchOptions(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	res, err := s.source.SearchContext(r.Context(), options)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, res)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
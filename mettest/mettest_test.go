@@ -0,0 +1,87 @@
+package mettest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lukasschwab/met"
+)
+
+func TestServer(t *testing.T) {
+	srv := NewServer(DefaultFixtures())
+	defer srv.Close()
+	c := srv.MetClient()
+
+	all, err := c.Objects(met.ObjectsOptions{})
+	if err != nil || all.Total != 6 {
+		t.Fatalf("Expected 6 objects, got %+v, %v", all, err)
+	}
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent, err := c.Objects(met.ObjectsOptions{MetadataDate: &since, DepartmentIDs: []int{10, 11}})
+	if err != nil || !reflect.DeepEqual(recent.ObjectIDs, []int{436524, 436535, 544442}) {
+		t.Errorf("Unexpected filtered objects: %+v, %v", recent, err)
+	}
+
+	obj, err := c.Object(met.ObjectOptions{ObjectID: 436535})
+	if err != nil || obj.Title != "Wheat Field with Cypresses" {
+		t.Errorf("Unexpected object: %+v, %v", obj, err)
+	}
+	if _, err := c.Object(met.ObjectOptions{ObjectID: -1}); !errors.Is(err, met.ErrObjectNotFound) {
+		t.Errorf("Expected not found error, got: %v", err)
+	}
+
+	depts, err := c.Departments()
+	if err != nil || depts.Departments[0].DepartmentID != 1 {
+		t.Errorf("Unexpected departments: %+v, %v", depts, err)
+	}
+}
+
+func TestServerSearch(t *testing.T) {
+	srv := NewServer(DefaultFixtures())
+	defer srv.Close()
+	c := srv.MetClient()
+
+	cases := []struct {
+		options  met.SearchOptions
+		expected []int
+	}{
+		{met.SearchOptions{Q: "sunflowers"}, []int{337066, 436524}},
+		{met.SearchOptions{Q: "sunflowers", IsOnView: true}, []int{436524}},
+		{met.SearchOptions{Q: "sunflowers", DepartmentID: 9}, []int{337066}},
+		{met.SearchOptions{Q: "japan", ArtistOrCulture: true}, []int{45734, 337066}},
+		{met.SearchOptions{Q: "*", Media: []string{"Textiles", "Faience"}}, []int{12706, 544442}},
+		{met.SearchOptions{Q: "*", GeoLocations: []string{"Paris"}}, []int{436524}},
+		{met.SearchOptions{Q: "*", YearRange: met.NewYearRange(-2000, 0)}, []int{544442}},
+		{met.SearchOptions{Q: "*", IsHighlight: true, HasImages: true}, []int{436535, 544442}},
+		{met.SearchOptions{Q: "nothing matches this"}, nil},
+	}
+	for _, tc := range cases {
+		res, err := c.Search(tc.options)
+		if err != nil {
+			t.Errorf("%+v: unexpected error: %v", tc.options, err)
+		} else if !reflect.DeepEqual(res.ObjectIDs, tc.expected) || res.Total != len(tc.expected) {
+			t.Errorf("%+v: expected %v, got %+v", tc.options, tc.expected, res)
+		}
+	}
+}
+
+func TestServerMutation(t *testing.T) {
+	srv := NewServer(Fixtures{})
+	defer srv.Close()
+	c := srv.MetClient()
+	c.DisableCoalescing = true
+
+	srv.Put(met.ObjectResult{ObjectID: 1, Title: "Added"})
+	if obj, err := c.Object(met.ObjectOptions{ObjectID: 1}); err != nil || obj.Title != "Added" {
+		t.Errorf("Unexpected object: %+v, %v", obj, err)
+	}
+	srv.Delete(1)
+	if _, err := c.Object(met.ObjectOptions{ObjectID: 1}); !errors.Is(err, met.ErrObjectNotFound) {
+		t.Errorf("Expected not found error, got: %v", err)
+	}
+	if srv.Requests() != 2 {
+		t.Errorf("Expected 2 requests, got %d", srv.Requests())
+	}
+}
@@ -0,0 +1,80 @@
+package mettest
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lukasschwab/met"
+	opt "github.com/lukasschwab/optional/pkg/optional"
+)
+
+// parseObjectsOptions parses the query parameters set by met.ObjectsOptions.
+func parseObjectsOptions(query url.Values) (met.ObjectsOptions, error) {
+	var options met.ObjectsOptions
+	if v := query.Get("metadataDate"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return options, fmt.Errorf("invalid metadataDate")
+		}
+		options.MetadataDate = &t
+	}
+	if v := query.Get("departmentIds"); v != "" {
+		for _, id := range strings.Split(v, "|") {
+			n, err := strconv.Atoi(id)
+			if err != nil {
+				return options, fmt.Errorf("invalid departmentIds")
+			}
+			options.DepartmentIDs = append(options.DepartmentIDs, n)
+		}
+	}
+	return options, nil
+}
+
+// parseSearchOptions parses the query parameters set by met.SearchOptions.
+func parseSearchOptions(query url.Values) (met.SearchOptions, error) {
+	var options met.SearchOptions
+	if !query.Has("q") {
+		return options, fmt.Errorf("missing q")
+	}
+	options.Q = query.Get("q")
+	bools := map[string]*opt.Bool{
+		"isHighlight":     &options.IsHighlight,
+		"isOnView":        &options.IsOnView,
+		"artistOrCulture": &options.ArtistOrCulture,
+		"hasImages":       &options.HasImages,
+	}
+	for name, dst := range bools {
+		if v := query.Get(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return options, fmt.Errorf("invalid %s", name)
+			}
+			*dst = b
+		}
+	}
+	if v := query.Get("departmentId"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return options, fmt.Errorf("invalid departmentId")
+		}
+		options.DepartmentID = id
+	}
+	if v := query.Get("medium"); v != "" {
+		options.Media = strings.Split(v, "|")
+	}
+	if v := query.Get("geoLocations"); v != "" {
+		options.GeoLocations = strings.Split(v, "|")
+	}
+	if query.Has("dateBegin") || query.Has("dateEnd") {
+		begin, errBegin := strconv.Atoi(query.Get("dateBegin"))
+		end, errEnd := strconv.Atoi(query.Get("dateEnd"))
+		if errBegin != nil || errEnd != nil {
+			return options, fmt.Errorf("dateBegin and dateEnd must be integers used together")
+		}
+		options.YearRange = met.NewYearRange(begin, end)
+	}
+	return options, nil
+}
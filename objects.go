@@ -10,8 +10,9 @@ import (
 // ObjectsOptions encapsulates arguments for the Met API Objects endpoint. See
 // https://metmuseum.github.io/#objects
 type ObjectsOptions struct {
-	// MetadataDate restricts Options results to objects updated after the day
-	// of the specified time.
+	// MetadataDate restricts Objects results to objects updated after the day
+	// of the specified time, i.e. on a later day. Only the date, in the time's
+	// location, is sent; objects updated later on the same day are excluded.
 	MetadataDate *time.Time
 	// DepartmentIDs restricts Options results to objects in the specified
 	// departments. See (c *Client).Departments().
@@ -0,0 +1,158 @@
+package met
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// ErrInvalidCursor is returned when resuming a Pager from a malformed cursor.
+var ErrInvalidCursor = errors.New("met: invalid cursor")
+
+// Pager divides the ObjectIDs in an ObjectsResult into fixed-size pages. The
+// Met API returns every matching ID at once; Pager lets callers walk them
+// incrementally and resume from an opaque cursor, e.g. across process
+// restarts or in a paged web API.
+//
+// Cursors identify a position by the last Object ID returned, so a cursor
+// remains meaningful against a fresh ObjectsResult in which objects have been
+// added or removed.
+type Pager struct {
+	ids    []int
+	sorted bool
+	size   int
+	offset int
+}
+
+// cursor is the decoded form of a Pager cursor.
+type cursor struct {
+	// Offset is the index of the first ID in the next page.
+	Offset int `json:"o"`
+	// After is the ID preceding Offset, if Offset is positive.
+	After int `json:"a,omitempty"`
+}
+
+// NewPager returns a Pager over result's ObjectIDs with pageSize IDs per page.
+// If pageSize is not positive, DefaultPageSize is used.
+func NewPager(result *ObjectsResult, pageSize int) *Pager {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Pager{
+		ids:    result.ObjectIDs,
+		sorted: sort.IntsAreSorted(result.ObjectIDs),
+		size:   pageSize,
+	}
+}
+
+// Total returns the number of IDs being paged.
+func (p *Pager) Total() int {
+	return len(p.ids)
+}
+
+// Pages returns the number of pages.
+func (p *Pager) Pages() int {
+	return (len(p.ids) + p.size - 1) / p.size
+}
+
+// Done reports whether every page has been returned by Next.
+func (p *Pager) Done() bool {
+	return p.offset >= len(p.ids)
+}
+
+// Next returns the next page of IDs and advances the Pager. It returns false
+// when there are no more pages.
+func (p *Pager) Next() ([]int, bool) {
+	if p.Done() {
+		return nil, false
+	}
+	page, next := p.pageAt(p.offset)
+	p.offset = next
+	return page, true
+}
+
+// Cursor returns an opaque token for the Pager's current position; see Seek.
+func (p *Pager) Cursor() string {
+	return p.encode(p.offset)
+}
+
+// Seek moves the Pager to the position identified by a cursor returned from
+// Cursor or Page. The empty cursor identifies the first page.
+func (p *Pager) Seek(cursor string) error {
+	offset, err := p.decode(cursor)
+	if err != nil {
+		return err
+	}
+	p.offset = offset
+	return nil
+}
+
+// Page returns the page of IDs at cursor and the cursor for the following
+// page, which is empty after the last page. Unlike Next, Page does not move
+// the Pager, so it is safe for concurrent use.
+func (p *Pager) Page(cursor string) (ids []int, next string, err error) {
+	offset, err := p.decode(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	ids, nextOffset := p.pageAt(offset)
+	if nextOffset < len(p.ids) {
+		next = p.encode(nextOffset)
+	}
+	return ids, next, nil
+}
+
+// pageAt returns the page starting at offset and the offset of the next page.
+func (p *Pager) pageAt(offset int) ([]int, int) {
+	end := offset + p.size
+	if end > len(p.ids) {
+		end = len(p.ids)
+	}
+	return p.ids[offset:end], end
+}
+
+func (p *Pager) encode(offset int) string {
+	c := cursor{Offset: offset}
+	if offset > 0 && offset <= len(p.ids) {
+		c.After = p.ids[offset-1]
+	}
+	// Marshaling a struct of ints cannot fail.
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decode returns the offset identified by a cursor.
+func (p *Pager) decode(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+	if c.Offset == 0 {
+		return 0, nil
+	}
+	// Prefer the position of the anchoring ID, which survives changes to the
+	// ID listing; fall back to the raw offset.
+	if c.Offset <= len(p.ids) && p.ids[c.Offset-1] == c.After {
+		return c.Offset, nil
+	}
+	if p.sorted {
+		return sort.Search(len(p.ids), func(i int) bool { return p.ids[i] > c.After }), nil
+	}
+	for i, id := range p.ids {
+		if id == c.After {
+			return i + 1, nil
+		}
+	}
+	if c.Offset > len(p.ids) {
+		return len(p.ids), nil
+	}
+	return c.Offset, nil
+}
@@ -0,0 +1,60 @@
+package met
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPager(t *testing.T) {
+	p := NewPager(&ObjectsResult{Total: 7, ObjectIDs: []int{1, 2, 3, 5, 8, 13, 21}}, 3)
+	if p.Pages() != 3 {
+		t.Errorf("Expected 3 pages, got %d", p.Pages())
+	}
+	var pages [][]int
+	for page, ok := p.Next(); ok; page, ok = p.Next() {
+		pages = append(pages, page)
+	}
+	expected := [][]int{{1, 2, 3}, {5, 8, 13}, {21}}
+	if !reflect.DeepEqual(pages, expected) {
+		t.Errorf("Expected pages %v, got %v", expected, pages)
+	}
+}
+
+func TestPagerResume(t *testing.T) {
+	original := NewPager(&ObjectsResult{ObjectIDs: []int{1, 2, 3, 5, 8, 13, 21}}, 3)
+	original.Next()
+	cursor := original.Cursor()
+
+	// Resuming against an unchanged listing continues where we left off.
+	resumed := NewPager(&ObjectsResult{ObjectIDs: []int{1, 2, 3, 5, 8, 13, 21}}, 3)
+	if err := resumed.Seek(cursor); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if page, _ := resumed.Next(); !reflect.DeepEqual(page, []int{5, 8, 13}) {
+		t.Errorf("Unexpected resumed page: %v", page)
+	}
+
+	// Resuming after an object before the cursor was removed (and the anchor
+	// itself deleted) neither skips nor repeats IDs.
+	changed := NewPager(&ObjectsResult{ObjectIDs: []int{1, 5, 8, 13, 21}}, 3)
+	page, next, err := changed.Page(cursor)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(page, []int{5, 8, 13}) {
+		t.Errorf("Unexpected page after removal: %v", page)
+	}
+	if page, next, _ = changed.Page(next); !reflect.DeepEqual(page, []int{21}) || next != "" {
+		t.Errorf("Unexpected final page %v with next cursor %q", page, next)
+	}
+}
+
+func TestPagerInvalidCursor(t *testing.T) {
+	p := NewPager(&ObjectsResult{ObjectIDs: []int{1, 2, 3}}, 2)
+	for _, cursor := range []string{"!!!", "bm90IGpzb24"} {
+		if err := p.Seek(cursor); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected invalid cursor error for %q, got: %v", cursor, err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package met
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MetRequestsPerSecond is the request rate limit documented by the Met API.
+const MetRequestsPerSecond = 80
+
+// RateLimiter is a token bucket limiting the rate of requests made by a
+// Client. It is safe for concurrent use, so a single RateLimiter can be shared
+// by every goroutine using a Client (or by several Clients).
+type RateLimiter struct {
+	mu sync.Mutex
+	// interval is the time it takes to accrue one token.
+	interval time.Duration
+	// burst is the maximum number of tokens the bucket holds.
+	burst float64
+	// tokens available as of last. Negative values represent requests
+	// that have reserved tokens and are waiting for them to accrue.
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing requestsPerSecond requests on
+// average, with bursts of up to burst requests. The bucket starts full.
+// NewRateLimiter panics if requestsPerSecond is not positive.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if !(requestsPerSecond > 0) {
+		panic(fmt.Sprintf("met: non-positive rate %v for NewRateLimiter", requestsPerSecond))
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// NewMetRateLimiter returns a RateLimiter enforcing the Met API's documented
+// limit of MetRequestsPerSecond, without bursting past it.
+func NewMetRateLimiter() *RateLimiter {
+	return NewRateLimiter(MetRequestsPerSecond, 1)
+}
+
+// advance accrues tokens for the time elapsed since l.last. l.mu must be held.
+func (l *RateLimiter) advance(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += float64(elapsed) / float64(l.interval)
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+	}
+}
+
+// delay returns how long a request would wait for a token, given the current
+// number of tokens. l.mu must be held.
+func (l *RateLimiter) delay() time.Duration {
+	if l.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - l.tokens) * float64(l.interval))
+}
+
+// Wait blocks until a request may proceed or ctx is done, in which case it
+// returns ctx.Err().
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.advance(time.Now())
+	wait := l.delay()
+	l.tokens--
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Return the reserved token for use by other requests.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// WaitTime returns how long a request made now would wait for the limiter.
+func (l *RateLimiter) WaitTime() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.advance(time.Now())
+	return l.delay()
+}
@@ -0,0 +1,73 @@
+package met
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterThrottlesConcurrentRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"objectID": 1}`))
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.Limiter = NewRateLimiter(200, 2)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			if _, err := c.Object(ObjectOptions{ObjectID: id}); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	// Two requests use the burst; the remaining ten wait 5ms each.
+	if elapsed := time.Since(start); elapsed < 45*time.Millisecond {
+		t.Errorf("Requests completed too quickly: %s", elapsed)
+	}
+}
+
+func TestRateLimiterWaitTime(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	if wait := l.WaitTime(); wait != 0 {
+		t.Errorf("Full bucket should not wait, got %s", wait)
+	}
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if wait := l.WaitTime(); wait <= 900*time.Millisecond {
+		t.Errorf("Empty bucket should wait about a second, got %s", wait)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got: %v", err)
+	}
+	if wait := l.WaitTime(); wait > time.Second {
+		t.Errorf("Cancelled wait should release its token, got wait %s", wait)
+	}
+}
+
+func TestNewRateLimiterRejectsNonPositiveRates(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for rate %v", rate)
+				}
+			}()
+			NewRateLimiter(rate, 1)
+		}()
+	}
+}
@@ -0,0 +1,134 @@
+package met
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"time"
+)
+
+// RetryPolicy configures how a Client retries requests that fail for
+// transient reasons. See DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts per request, including
+	// the first. Values less than 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, excluding delays requested by
+	// a Retry-After header.
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest delay requested by a Retry-After header
+	// that the policy honors. If a response asks the client to wait longer,
+	// the request is not retried and its error is returned, rather than
+	// stalling the caller. If unspecified, MaxBackoff is used; if both are
+	// unspecified, every Retry-After delay is honored.
+	MaxRetryAfter time.Duration
+	// Multiplier scales the delay after each failed attempt. Values less than
+	// 1 are treated as 1.
+	Multiplier float64
+	// Jitter randomizes each delay by up to this fraction of its length, e.g.
+	// 0.2 yields delays between 80% and 120% of the backoff curve.
+	Jitter float64
+	// RetryableStatusCodes lists the HTTP status codes that are retried.
+	RetryableStatusCodes []int
+	// RetryNetworkErrors enables retrying network errors and truncated
+	// response bodies.
+	RetryNetworkErrors bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for bulk jobs: up to five
+// attempts with exponential backoff from half a second, retrying the status
+// codes the Met API returns when it is overloaded or blocking a client.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          5,
+		InitialBackoff:       500 * time.Millisecond,
+		MaxBackoff:           30 * time.Second,
+		MaxRetryAfter:        2 * time.Minute,
+		Multiplier:           2,
+		Jitter:               0.2,
+		RetryableStatusCodes: []int{403, 429, 500, 502, 503, 504},
+		RetryNetworkErrors:   true,
+	}
+}
+
+// retryable reports whether err is worth retrying under the policy.
+func (p *RetryPolicy) retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		for _, code := range p.RetryableStatusCodes {
+			if apiErr.StatusCode == code {
+				return true
+			}
+		}
+		return false
+	}
+	if !p.RetryNetworkErrors {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// maxRetryAfter returns the longest Retry-After delay the policy honors, or
+// zero if there is no limit.
+func (p *RetryPolicy) maxRetryAfter() time.Duration {
+	if p.MaxRetryAfter > 0 {
+		return p.MaxRetryAfter
+	}
+	return p.MaxBackoff
+}
+
+// backoff returns the delay before retrying after the failed attempt number
+// attempt (counting from 1), which failed with err. It returns false if err
+// requests a longer delay than the policy honors.
+func (p *RetryPolicy) backoff(attempt int, err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if limit := p.maxRetryAfter(); limit > 0 && apiErr.RetryAfter > limit {
+			return 0, false
+		}
+		return apiErr.RetryAfter, true
+	}
+	multiplier := math.Max(p.Multiplier, 1)
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+	if p.MaxBackoff > 0 {
+		delay = math.Min(delay, float64(p.MaxBackoff))
+	}
+	return time.Duration(delay), true
+}
+
+// withRetries calls attempt until it succeeds, returns an error the policy
+// does not retry, requests a longer Retry-After delay than the policy honors,
+// or exhausts the policy's MaxAttempts. A nil policy makes a single attempt.
+func (p *RetryPolicy) withRetries(ctx context.Context, attempt func() error) error {
+	err := attempt()
+	if p == nil {
+		return err
+	}
+	for n := 1; err != nil && n < p.MaxAttempts && p.retryable(err); n++ {
+		delay, ok := p.backoff(n, err)
+		if !ok {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		err = attempt()
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package met
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failingServer returns a server that responds with status to the first
+// failures requests, then serves an empty departments listing.
+func failingServer(failures int32, status int) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"departments": []}`))
+	}))
+	return server, &requests
+}
+
+func testRetryPolicy() *RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.InitialBackoff = time.Millisecond
+	p.MaxBackoff = 5 * time.Millisecond
+	return p
+}
+
+func TestRetrySucceedsAfterTransientFailures(t *testing.T) {
+	server, requests := failingServer(3, http.StatusBadGateway)
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.Retry = testRetryPolicy()
+
+	if _, err := c.Departments(); err != nil {
+		t.Errorf("Expected success after retries, got: %v", err)
+	}
+	if *requests != 4 {
+		t.Errorf("Expected 4 requests, got %d", *requests)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server, requests := failingServer(10, http.StatusTooManyRequests)
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.Retry = testRetryPolicy()
+	c.Retry.MaxAttempts = 3
+
+	if _, err := c.Departments(); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected rate limit error, got: %v", err)
+	}
+	if *requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", *requests)
+	}
+}
+
+func TestRetrySkipsPermanentFailures(t *testing.T) {
+	server, requests := failingServer(10, http.StatusNotFound)
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.Retry = testRetryPolicy()
+
+	if _, err := c.Departments(); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected not found error, got: %v", err)
+	}
+	if *requests != 1 {
+		t.Errorf("Expected 1 request, got %d", *requests)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := &RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+		MaxRetryAfter:  2 * time.Minute,
+		Multiplier:     2,
+	}
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		if got, ok := p.backoff(attempt+1, errors.New("boom")); !ok || got != expected {
+			t.Errorf("Attempt %d: expected backoff %s, got %s", attempt+1, expected, got)
+		}
+	}
+	jittered := *p
+	jittered.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got, _ := jittered.backoff(10, errors.New("boom")); got > jittered.MaxBackoff || got < jittered.MaxBackoff/2 {
+			t.Fatalf("Jittered backoff %s outside [%s, %s]", got, jittered.MaxBackoff/2, jittered.MaxBackoff)
+		}
+	}
+	retryAfter := &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Minute}
+	if got, ok := p.backoff(1, retryAfter); !ok || got != time.Minute {
+		t.Errorf("Expected Retry-After to override backoff, got %s", got)
+	}
+	retryAfter.RetryAfter = 24 * time.Hour
+	if _, ok := p.backoff(1, retryAfter); ok {
+		t.Errorf("Expected policy to give up on Retry-After beyond MaxRetryAfter")
+	}
+	p.MaxRetryAfter = 0
+	retryAfter.RetryAfter = 10 * time.Second
+	if _, ok := p.backoff(1, retryAfter); ok {
+		t.Errorf("Expected MaxBackoff to cap Retry-After when MaxRetryAfter is unspecified")
+	}
+}
+
+func TestRetryGivesUpOnLongRetryAfter(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.Retry = testRetryPolicy()
+
+	start := time.Now()
+	if _, err := c.Departments(); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected rate limit error, got: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 || time.Since(start) > 5*time.Second {
+		t.Errorf("Expected one prompt request, got %d in %s", n, time.Since(start))
+	}
+}
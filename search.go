@@ -1,7 +1,9 @@
 package met
 
 import (
+	"iter"
 	"net/url"
+	"strings"
 
 	opt "github.com/lukasschwab/optional/pkg/optional"
 	opturl "github.com/lukasschwab/optional/pkg/url"
@@ -31,19 +33,9 @@ type SearchOptions struct {
 	// GeoLocations restricts results to objects matching one or more
 	// locations, e.g. "Europe", "France", "Paris", "China", "New York."
 	GeoLocations []string
-	// YearRange restricts results to a range of years. See NewYearRange.
-	YearRange *yearRange
-}
-
-type yearRange struct {
-	yearBegin int
-	yearEnd   int
-}
-
-// NewYearRange returns a yearRange (from yearBegin to yearEnd) for use in
-// SearchOptions.
-func NewYearRange(yearBegin, yearEnd int) *yearRange {
-	return &yearRange{yearBegin, yearEnd}
+	// YearRange restricts results to objects dated within a range of years.
+	// See NewYearRange, Since, Until, Century, and Decade.
+	YearRange *YearRange
 }
 
 func (options SearchOptions) toQuery() url.Values {
@@ -57,8 +49,137 @@ func (options SearchOptions) toQuery() url.Values {
 	opturl.AddBoolToQuery(&query, "hasImages", options.HasImages, opturl.DefaultBoolFormatter)
 	opturl.AddSliceToQuery(&query, "geoLocations", options.GeoLocations, opturl.SeparatorFormatter("|"))
 	if options.YearRange != nil {
-		opturl.AddIntToQuery(&query, "dateBegin", options.YearRange.yearBegin, opturl.DefaultIntFormatter)
-		opturl.AddIntToQuery(&query, "dateEnd", options.YearRange.yearEnd, opturl.DefaultIntFormatter)
+		begin, end := options.YearRange.bounds()
+		opturl.AddIntToQuery(&query, "dateBegin", begin, opturl.DefaultIntFormatter)
+		opturl.AddIntToQuery(&query, "dateEnd", end, opturl.DefaultIntFormatter)
 	}
 	return query
 }
+
+// Matches reports whether obj satisfies options, evaluated locally. It
+// resolves DepartmentID against MetDepartments, a snapshot that may be out of
+// date; use MatchesIn to resolve it against a current listing, e.g. from
+// Client.Departments or DepartmentRegistry.Departments.
+//
+// The Met API does not document its search semantics, so Matches
+// approximates them:
+//
+//   - Q matches case-insensitively as a substring of the title, artist name,
+//     culture, object name, medium, classification, credit line, period,
+//     dynasty, or tags. The server searches all metadata with its own
+//     tokenization, so it may match objects Matches does not. An empty Q, or
+//     "*", matches every object.
+//   - ArtistOrCulture restricts Q to the artist name and culture.
+//   - IsOnView is judged by a non-empty GalleryNumber, and HasImages by a
+//     non-empty PrimaryImage.
+//   - Media matches as a substring of the classification, medium, or object
+//     name. GeoLocations matches as a substring of the city, state, county,
+//     country, region, subregion, or locale; unlike the server, it does not
+//     know that e.g. "Europe" contains "France".
+//   - YearRange matches objects it Contains.
+func (options SearchOptions) Matches(obj *ObjectResult) bool {
+	return options.MatchesIn(obj, metDepartments)
+}
+
+// MatchesIn is like Matches, but resolves DepartmentID against departments.
+// An unknown DepartmentID matches no objects.
+func (options SearchOptions) MatchesIn(obj *ObjectResult, departments []Department) bool {
+	return options.matches(obj, departmentNamer(departments))
+}
+
+// Filter returns the objects that satisfy options, per Matches.
+func (options SearchOptions) Filter(objects []*ObjectResult) []*ObjectResult {
+	return options.FilterIn(objects, metDepartments)
+}
+
+// FilterIn returns the objects that satisfy options, per MatchesIn.
+func (options SearchOptions) FilterIn(objects []*ObjectResult, departments []Department) []*ObjectResult {
+	var matching []*ObjectResult
+	for _, obj := range objects {
+		if options.MatchesIn(obj, departments) {
+			matching = append(matching, obj)
+		}
+	}
+	return matching
+}
+
+// FilterSeq returns an iterator over the objects yielded by seq that satisfy
+// options, per Matches. Errors from seq are passed through.
+func (options SearchOptions) FilterSeq(seq iter.Seq2[*ObjectResult, error]) iter.Seq2[*ObjectResult, error] {
+	return options.FilterSeqIn(seq, metDepartments)
+}
+
+// FilterSeqIn is like FilterSeq, but matches objects per MatchesIn.
+func (options SearchOptions) FilterSeqIn(seq iter.Seq2[*ObjectResult, error], departments []Department) iter.Seq2[*ObjectResult, error] {
+	departmentName := departmentNamer(departments)
+	return func(yield func(*ObjectResult, error) bool) {
+		for obj, err := range seq {
+			if err != nil || options.matches(obj, departmentName) {
+				if !yield(obj, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// matches reports whether obj satisfies options, per Matches. departmentName
+// resolves DepartmentID to a department name.
+func (options SearchOptions) matches(obj *ObjectResult, departmentName func(int) (string, bool)) bool {
+	if options.IsHighlight != nil && obj.IsHighlight != opt.ToBool(options.IsHighlight) {
+		return false
+	}
+	if options.IsOnView != nil && (obj.GalleryNumber != "") != opt.ToBool(options.IsOnView) {
+		return false
+	}
+	if options.HasImages != nil && (obj.PrimaryImage != "") != opt.ToBool(options.HasImages) {
+		return false
+	}
+	if options.DepartmentID != nil {
+		name, ok := departmentName(opt.ToInt(options.DepartmentID))
+		if !ok || !strings.EqualFold(obj.Department, name) {
+			return false
+		}
+	}
+	if len(options.Media) > 0 && !containsAnyFold(options.Media, obj.Classification, obj.Medium, obj.ObjectName) {
+		return false
+	}
+	if len(options.GeoLocations) > 0 && !containsAnyFold(options.GeoLocations, obj.City, obj.State, obj.County, obj.Country, obj.Region, obj.Subregion, obj.Locale) {
+		return false
+	}
+	if options.YearRange != nil && !options.YearRange.Contains(obj) {
+		return false
+	}
+	return options.matchesQ(obj)
+}
+
+// matchesQ reports whether Q occurs in obj's searchable fields. The empty
+// query and the wildcard "*" match every object.
+func (options SearchOptions) matchesQ(obj *ObjectResult) bool {
+	q := strings.TrimSpace(options.Q)
+	if q == "" || q == "*" {
+		return true
+	}
+	fields := []string{obj.ArtistDisplayName, obj.Culture}
+	if options.ArtistOrCulture == nil || !opt.ToBool(options.ArtistOrCulture) {
+		fields = append(fields, obj.Title, obj.ObjectName, obj.Medium, obj.Classification, obj.CreditLine, obj.Period, obj.Dynasty)
+		for _, tag := range obj.Tags {
+			fields = append(fields, tag.Term)
+		}
+	}
+	return containsAnyFold([]string{q}, fields...)
+}
+
+// containsAnyFold reports whether any of fields contains any of values,
+// ignoring case.
+func containsAnyFold(values []string, fields ...string) bool {
+	for _, v := range values {
+		v = strings.ToLower(v)
+		for _, field := range fields {
+			if field != "" && strings.Contains(strings.ToLower(field), v) {
+				return true
+			}
+		}
+	}
+	return false
+}
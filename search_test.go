@@ -0,0 +1,117 @@
+package met
+
+import (
+	"errors"
+	"iter"
+	"testing"
+)
+
+func TestSearchOptionsMatches(t *testing.T) {
+	wheatField := &ObjectResult{
+		ObjectID:          436535,
+		Title:             "Wheat Field with Cypresses",
+		ArtistDisplayName: "Vincent van Gogh",
+		Department:        "European Paintings",
+		Classification:    "Paintings",
+		Medium:            "Oil on canvas",
+		Country:           "France",
+		ObjectBeginDate:   1889,
+		ObjectEndDate:     1889,
+		GalleryNumber:     "822",
+		IsHighlight:       true,
+	}
+	cases := []struct {
+		options  SearchOptions
+		expected bool
+	}{
+		{SearchOptions{Q: "cypresses"}, true},
+		{SearchOptions{Q: "*"}, true},
+		{SearchOptions{Q: "cypresses", ArtistOrCulture: true}, false},
+		{SearchOptions{Q: "gogh", ArtistOrCulture: true}, true},
+		{SearchOptions{IsHighlight: true, IsOnView: true, HasImages: false}, true},
+		{SearchOptions{HasImages: true}, false},
+		{SearchOptions{DepartmentID: 11}, true},
+		{SearchOptions{DepartmentID: 9}, false},
+		{SearchOptions{Media: []string{"Sculpture", "paintings"}}, true},
+		{SearchOptions{GeoLocations: []string{"France"}}, true},
+		{SearchOptions{GeoLocations: []string{"Europe"}}, false},
+		{SearchOptions{YearRange: NewYearRange(1880, 1890)}, true},
+		{SearchOptions{YearRange: NewYearRange(1890, 1900)}, false},
+	}
+	for _, tc := range cases {
+		if got := tc.options.Matches(wheatField); got != tc.expected {
+			t.Errorf("%+v: expected %t, got %t", tc.options, tc.expected, got)
+		}
+	}
+	if (SearchOptions{DepartmentID: 11}).MatchesIn(wheatField, testDepartments[:1]) {
+		t.Errorf("Unknown department should match no objects")
+	}
+}
+
+func TestSearchOptionsFilter(t *testing.T) {
+	objects := []*ObjectResult{
+		{ObjectID: 1, Title: "Sunflowers"},
+		{ObjectID: 2, Title: "Irises"},
+		{ObjectID: 3, Title: "Sunflowers and Butterflies"},
+	}
+	options := SearchOptions{Q: "sunflowers"}
+	if got := options.Filter(objects); len(got) != 2 || got[1].ObjectID != 3 {
+		t.Errorf("Unexpected filtered objects: %v", got)
+	}
+
+	failure := errors.New("boom")
+	seq := func(yield func(*ObjectResult, error) bool) {
+		for _, obj := range objects {
+			if !yield(obj, nil) {
+				return
+			}
+		}
+		yield(nil, failure)
+	}
+	var ids []int
+	var errs []error
+	for obj, err := range options.FilterSeq(iter.Seq2[*ObjectResult, error](seq)) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, obj.ObjectID)
+	}
+	if !sameIDs(ids, []int{1, 3}) || len(errs) != 1 || errs[0] != failure {
+		t.Errorf("Unexpected filtered stream: %v, %v", ids, errs)
+	}
+}
+
+func TestSearchOptionsFilterIn(t *testing.T) {
+	objects := []*ObjectResult{
+		{ObjectID: 1, Department: "Digital Art"},
+		{ObjectID: 2, Department: "European Paintings"},
+	}
+	departments := append(MetDepartments(), Department{DepartmentID: 99, DisplayName: "Digital Art"})
+	options := SearchOptions{DepartmentID: 99}
+	if got := options.Filter(objects); len(got) != 0 {
+		t.Errorf("Department absent from the snapshot should match nothing: %v", got)
+	}
+	if got := options.FilterIn(objects, departments); len(got) != 1 || got[0].ObjectID != 1 {
+		t.Errorf("Unexpected filtered objects: %v", got)
+	}
+	seq := func(yield func(*ObjectResult, error) bool) {
+		for _, obj := range objects {
+			if !yield(obj, nil) {
+				return
+			}
+		}
+	}
+	var ids []int
+	for obj := range options.FilterSeqIn(seq, departments) {
+		ids = append(ids, obj.ObjectID)
+	}
+	if !sameIDs(ids, []int{1}) {
+		t.Errorf("Unexpected filtered stream: %v", ids)
+	}
+
+	MetDepartments()[0].DisplayName = "Changed"
+	if MetDepartments()[0].DisplayName == "Changed" {
+		t.Errorf("MetDepartments should return a copy")
+	}
+}
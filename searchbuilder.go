@@ -0,0 +1,160 @@
+package met
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	opt "github.com/lukasschwab/optional/pkg/optional"
+)
+
+// ErrInvalidSearch matches errors describing invalid SearchOptions, returned
+// by SearchOptions.Validate and SearchBuilder.
+var ErrInvalidSearch = errors.New("met: invalid search")
+
+// Validate checks options for mistakes the Met API would not report: an empty
+// Q, a YearRange that ends before it begins, and empty Media or GeoLocations
+// values. The returned error matches ErrInvalidSearch and describes every
+// problem found. Validate does not check that DepartmentID exists; see
+// SearchBuilder.BuildContext.
+func (options SearchOptions) Validate() error {
+	var errs []error
+	invalid := func(format string, a ...interface{}) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidSearch}, a...)...))
+	}
+	if strings.TrimSpace(options.Q) == "" {
+		invalid("empty query; use \"*\" to match every object")
+	}
+	if r := options.YearRange; r != nil && r.IsEmpty() {
+		invalid("year range begins (%d) after it ends (%d)", r.begin, r.end)
+	}
+	for _, medium := range options.Media {
+		if strings.TrimSpace(medium) == "" {
+			invalid("empty medium")
+		}
+	}
+	for _, location := range options.GeoLocations {
+		if strings.TrimSpace(location) == "" {
+			invalid("empty geolocation")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SearchBuilder constructs validated SearchOptions fluently:
+//
+//	options, err := NewSearchBuilder("sunflowers").
+//	  Department(11).
+//	  OnView(true).
+//	  Years(1850, 1900).
+//	  BuildContext(ctx, c)
+//
+// Setters return the builder, so calls may be chained. Problems are reported
+// when the options are built, before any search request is made.
+type SearchBuilder struct {
+	options SearchOptions
+}
+
+// NewSearchBuilder returns a SearchBuilder for objects matching q.
+func NewSearchBuilder(q string) *SearchBuilder {
+	return &SearchBuilder{options: SearchOptions{Q: q}}
+}
+
+// Highlights restricts results to objects that are, or are not, highlights.
+func (b *SearchBuilder) Highlights(isHighlight bool) *SearchBuilder {
+	b.options.IsHighlight = isHighlight
+	return b
+}
+
+// Department restricts results to objects in the department with the
+// specified ID.
+func (b *SearchBuilder) Department(departmentID int) *SearchBuilder {
+	b.options.DepartmentID = departmentID
+	return b
+}
+
+// OnView restricts results to objects that are, or are not, on view.
+func (b *SearchBuilder) OnView(isOnView bool) *SearchBuilder {
+	b.options.IsOnView = isOnView
+	return b
+}
+
+// ArtistOrCulture restricts the query to artist names and cultures.
+func (b *SearchBuilder) ArtistOrCulture() *SearchBuilder {
+	b.options.ArtistOrCulture = true
+	return b
+}
+
+// Media restricts results to objects matching one or more media, in addition
+// to any media already specified.
+func (b *SearchBuilder) Media(media ...string) *SearchBuilder {
+	b.options.Media = append(b.options.Media, media...)
+	return b
+}
+
+// Images restricts results to objects with, or without, images.
+func (b *SearchBuilder) Images(hasImages bool) *SearchBuilder {
+	b.options.HasImages = hasImages
+	return b
+}
+
+// GeoLocations restricts results to objects matching one or more locations,
+// in addition to any locations already specified.
+func (b *SearchBuilder) GeoLocations(locations ...string) *SearchBuilder {
+	b.options.GeoLocations = append(b.options.GeoLocations, locations...)
+	return b
+}
+
+// Years restricts results to objects dated between yearBegin and yearEnd.
+func (b *SearchBuilder) Years(yearBegin, yearEnd int) *SearchBuilder {
+	return b.During(NewYearRange(yearBegin, yearEnd))
+}
+
+// During restricts results to objects dated within r.
+func (b *SearchBuilder) During(r *YearRange) *SearchBuilder {
+	b.options.YearRange = r
+	return b
+}
+
+// Build returns the built SearchOptions, or an error if they are invalid per
+// SearchOptions.Validate. Build makes no requests, so it does not check that
+// the department exists; see BuildContext.
+func (b *SearchBuilder) Build() (SearchOptions, error) {
+	options := b.options
+	options.Media = append([]string(nil), b.options.Media...)
+	options.GeoLocations = append([]string(nil), b.options.GeoLocations...)
+	if err := options.Validate(); err != nil {
+		return SearchOptions{}, err
+	}
+	return options, nil
+}
+
+// BuildContext is like Build, but also checks that the department, if any, is
+// among those listed by src. src is consulted only if the options are
+// otherwise valid.
+func (b *SearchBuilder) BuildContext(ctx context.Context, src Source) (SearchOptions, error) {
+	options, err := b.Build()
+	if err != nil || options.DepartmentID == nil {
+		return options, err
+	}
+	res, err := src.DepartmentsContext(ctx)
+	if err != nil {
+		return SearchOptions{}, fmt.Errorf("failed listing departments: %w", err)
+	}
+	id := opt.ToInt(options.DepartmentID)
+	if _, ok := departmentNamer(res.Departments)(id); !ok {
+		return SearchOptions{}, fmt.Errorf("%w: no department with ID %d", ErrInvalidSearch, id)
+	}
+	return options, nil
+}
+
+// SearchContext builds the options with BuildContext and, if they are valid,
+// runs the search against src.
+func (b *SearchBuilder) SearchContext(ctx context.Context, src Source) (*ObjectsResult, error) {
+	options, err := b.BuildContext(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	return src.SearchContext(ctx, options)
+}
@@ -0,0 +1,82 @@
+package met
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSearchBuilderValidation(t *testing.T) {
+	cases := []struct {
+		builder  *SearchBuilder
+		contains []string
+	}{
+		{NewSearchBuilder(" "), []string{"empty query"}},
+		{NewSearchBuilder("sunflowers").Years(1900, 1850), []string{"begins (1900) after it ends (1850)"}},
+		{NewSearchBuilder("").Media("Paintings", ""), []string{"empty query", "empty medium"}},
+		{NewSearchBuilder("*").GeoLocations(""), []string{"empty geolocation"}},
+	}
+	for _, tc := range cases {
+		_, err := tc.builder.Build()
+		if !errors.Is(err, ErrInvalidSearch) {
+			t.Errorf("Expected invalid search error, got: %v", err)
+			continue
+		}
+		for _, s := range tc.contains {
+			if !strings.Contains(err.Error(), s) {
+				t.Errorf("Expected error to mention %q, got: %v", s, err)
+			}
+		}
+	}
+
+	options, err := NewSearchBuilder("sunflowers").Highlights(true).Media("Paintings").Years(1850, 1900).Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q := options.toQuery().Encode(); q != "dateBegin=1850&dateEnd=1900&isHighlight=true&medium=Paintings&q=sunflowers" {
+		t.Errorf("Unexpected query: %s", q)
+	}
+}
+
+func TestSearchBuilderChecksDepartment(t *testing.T) {
+	var departments, searches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/departments":
+			atomic.AddInt32(&departments, 1)
+			json.NewEncoder(w).Encode(DepartmentsResult{Departments: testDepartments})
+		case "/search":
+			atomic.AddInt32(&searches, 1)
+			w.Write([]byte(`{"total": 1, "objectIDs": [1]}`))
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.DisableCoalescing = true
+	ctx := context.Background()
+
+	if _, err := NewSearchBuilder("").Department(11).SearchContext(ctx, c); !errors.Is(err, ErrInvalidSearch) {
+		t.Errorf("Expected invalid search error, got: %v", err)
+	}
+	if atomic.LoadInt32(&departments) != 0 || atomic.LoadInt32(&searches) != 0 {
+		t.Errorf("Expected no requests for invalid options, got %d, %d", departments, searches)
+	}
+
+	_, err := NewSearchBuilder("sunflowers").Department(99).SearchContext(ctx, c)
+	if !errors.Is(err, ErrInvalidSearch) || !strings.Contains(err.Error(), "no department with ID 99") {
+		t.Errorf("Expected unknown department error, got: %v", err)
+	}
+	if atomic.LoadInt32(&searches) != 0 {
+		t.Errorf("Expected no search requests for unknown department, got %d", searches)
+	}
+
+	res, err := NewSearchBuilder("sunflowers").Department(testDepartments[0].DepartmentID).SearchContext(ctx, c)
+	if err != nil || res.Total != 1 || atomic.LoadInt32(&searches) != 1 {
+		t.Errorf("Unexpected result: %v, %v, %d searches", res, err, searches)
+	}
+}
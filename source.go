@@ -0,0 +1,203 @@
+package met
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source is a source of Met collection data. It is implemented by Client,
+// which queries the Met API, and by MemorySource, which serves records loaded
+// from fixtures, the Open Access CSV dump, or a harvested local store.
+type Source interface {
+	// ObjectsContext lists Object IDs; see Client.Objects.
+	ObjectsContext(ctx context.Context, options ObjectsOptions) (*ObjectsResult, error)
+	// ObjectContext returns an object's record; see Client.Object. Sources
+	// return an error matching ErrObjectNotFound for unknown objects.
+	ObjectContext(ctx context.Context, options ObjectOptions) (*ObjectResult, error)
+	// DepartmentsContext lists departments; see Client.Departments.
+	DepartmentsContext(ctx context.Context) (*DepartmentsResult, error)
+	// SearchContext lists the IDs of objects matching a search; see
+	// Client.Search.
+	SearchContext(ctx context.Context, options SearchOptions) (*ObjectsResult, error)
+}
+
+var (
+	_ Source = (*Client)(nil)
+	_ Source = (*MemorySource)(nil)
+)
+
+// MemorySource is a Source serving object records held in memory. It searches
+// by approximating the Met API's search semantics locally. MemorySource is
+// safe for concurrent use; it implements Sink, so a Harvester can fill it.
+type MemorySource struct {
+	mu          sync.RWMutex
+	objects     map[int]*ObjectResult
+	departments []Department
+}
+
+// NewMemorySource returns a MemorySource serving objects and departments.
+// Objects are associated with departments by name, as in
+// ObjectResult.Department.
+func NewMemorySource(objects []*ObjectResult, departments []Department) *MemorySource {
+	s := &MemorySource{
+		objects:     make(map[int]*ObjectResult, len(objects)),
+		departments: departments,
+	}
+	for _, obj := range objects {
+		s.objects[obj.ObjectID] = obj
+	}
+	return s
+}
+
+// LoadCSV returns a MemorySource serving the objects in the Open Access CSV
+// dump read from r. The dump does not list departments, so they are provided
+// separately; see Client.Departments.
+func LoadCSV(r io.Reader, departments []Department) (*MemorySource, error) {
+	cr, err := NewCSVReader(r)
+	if err != nil {
+		return nil, err
+	}
+	s := NewMemorySource(nil, departments)
+	for obj, err := range cr.All() {
+		if err != nil {
+			return nil, err
+		}
+		s.Put(obj)
+	}
+	return s, nil
+}
+
+// LoadJSONLines returns a MemorySource serving the objects in a local store
+// of JSON lines read from r, e.g. one written by a Harvester to a
+// JSONLinesSink. If the store contains several records for an object, the
+// last is used.
+func LoadJSONLines(r io.Reader, departments []Department) (*MemorySource, error) {
+	s := NewMemorySource(nil, departments)
+	dec := json.NewDecoder(r)
+	for {
+		obj := new(ObjectResult)
+		if err := dec.Decode(obj); err == io.EOF {
+			return s, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed decoding object: %w", err)
+		}
+		s.Put(obj)
+	}
+}
+
+// Put adds obj to the source, replacing any object with the same ID.
+func (s *MemorySource) Put(obj *ObjectResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[obj.ObjectID] = obj
+}
+
+// Delete removes the object with the specified ID from the source.
+func (s *MemorySource) Delete(objectID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, objectID)
+}
+
+// Write implements Sink by adding obj to the source.
+func (s *MemorySource) Write(ctx context.Context, obj *ObjectResult) error {
+	s.Put(obj)
+	return nil
+}
+
+// ObjectsContext implements Source. It interprets options.MetadataDate as
+// documented by ObjectsOptions: only objects updated on a later day match.
+// Objects without a parseable MetadataDate are excluded when
+// options.MetadataDate is set.
+func (s *MemorySource) ObjectsContext(ctx context.Context, options ObjectsOptions) (*ObjectsResult, error) {
+	var departments map[string]bool
+	if len(options.DepartmentIDs) > 0 {
+		departments = map[string]bool{}
+		for _, id := range options.DepartmentIDs {
+			if name, ok := s.departmentName(id); ok {
+				departments[strings.ToLower(name)] = true
+			}
+		}
+	}
+	// nextDay is the start of the day after options.MetadataDate.
+	var nextDay time.Time
+	if options.MetadataDate != nil {
+		y, m, d := options.MetadataDate.Date()
+		nextDay = time.Date(y, m, d+1, 0, 0, 0, 0, options.MetadataDate.Location())
+	}
+	return s.filter(func(obj *ObjectResult) bool {
+		if departments != nil && !departments[strings.ToLower(obj.Department)] {
+			return false
+		}
+		if options.MetadataDate != nil {
+			updated, ok := parseMetadataDate(obj.MetadataDate)
+			if !ok || updated.Before(nextDay) {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+// ObjectContext implements Source. It returns a copy of the stored record.
+func (s *MemorySource) ObjectContext(ctx context.Context, options ObjectOptions) (*ObjectResult, error) {
+	s.mu.RLock()
+	obj, ok := s.objects[options.ObjectID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("object %d: %w", options.ObjectID, ErrObjectNotFound)
+	}
+	result := *obj
+	return &result, nil
+}
+
+// DepartmentsContext implements Source.
+func (s *MemorySource) DepartmentsContext(ctx context.Context) (*DepartmentsResult, error) {
+	return &DepartmentsResult{Departments: append([]Department(nil), s.departments...)}, nil
+}
+
+// SearchContext implements Source.
+func (s *MemorySource) SearchContext(ctx context.Context, options SearchOptions) (*ObjectsResult, error) {
+	return s.filter(func(obj *ObjectResult) bool {
+		return options.matches(obj, s.departmentName)
+	}), nil
+}
+
+// departmentName returns the name of the department with the specified ID.
+func (s *MemorySource) departmentName(id int) (string, bool) {
+	return departmentNamer(s.departments)(id)
+}
+
+// filter lists the IDs of the objects satisfying keep in ascending order, as
+// the Met API does. An empty listing has nil ObjectIDs.
+func (s *MemorySource) filter(keep func(*ObjectResult) bool) *ObjectsResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ids []int
+	for id, obj := range s.objects {
+		if keep(obj) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return &ObjectsResult{Total: len(ids), ObjectIDs: ids}
+}
+
+// metadataDateLayouts are the formats of ObjectResult.MetadataDate in API
+// responses and in the CSV dump, respectively.
+var metadataDateLayouts = []string{time.RFC3339, "1/2/2006 3:04:05 PM"}
+
+func parseMetadataDate(s string) (time.Time, bool) {
+	for _, layout := range metadataDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
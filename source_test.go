@@ -0,0 +1,68 @@
+package met
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testDepartments = []Department{
+	{DepartmentID: 1, DisplayName: "American Decorative Arts"},
+	{DepartmentID: 11, DisplayName: "European Paintings"},
+}
+
+func TestMemorySourceFromCSV(t *testing.T) {
+	var s Source
+	s, err := LoadCSV(strings.NewReader(testCSV), testDepartments)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	res, err := s.ObjectsContext(ctx, ObjectsOptions{DepartmentIDs: []int{11}})
+	if err != nil || !reflect.DeepEqual(res.ObjectIDs, []int{436535}) {
+		t.Errorf("Unexpected department listing: %+v, %v", res, err)
+	}
+	res, err = s.SearchContext(ctx, SearchOptions{Q: "liberty", IsHighlight: true})
+	if err != nil || !reflect.DeepEqual(res.ObjectIDs, []int{1}) {
+		t.Errorf("Unexpected search results: %+v, %v", res, err)
+	}
+	res, err = s.SearchContext(ctx, SearchOptions{Q: "gogh", DepartmentID: 1})
+	if err != nil || res.Total != 0 || res.ObjectIDs != nil {
+		t.Errorf("Expected no search results: %+v, %v", res, err)
+	}
+	if _, err := s.ObjectContext(ctx, ObjectOptions{ObjectID: 2}); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected not found error, got: %v", err)
+	}
+	depts, err := s.DepartmentsContext(ctx)
+	if err != nil || !reflect.DeepEqual(depts.Departments, testDepartments) {
+		t.Errorf("Unexpected departments: %+v, %v", depts, err)
+	}
+}
+
+func TestMemorySourceFromJSONLines(t *testing.T) {
+	var store bytes.Buffer
+	enc := json.NewEncoder(&store)
+	enc.Encode(ObjectResult{ObjectID: 1, Title: "Old", MetadataDate: "2020-01-01T00:00:00Z"})
+	enc.Encode(ObjectResult{ObjectID: 2, Title: "Recent", MetadataDate: "2024-06-01T12:00:00Z"})
+	enc.Encode(ObjectResult{ObjectID: 1, Title: "New", MetadataDate: "2024-06-02T00:00:00Z"})
+
+	s, err := LoadJSONLines(&store, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	obj, err := s.ObjectContext(context.Background(), ObjectOptions{ObjectID: 1})
+	if err != nil || obj.Title != "New" {
+		t.Errorf("Expected last record for object 1, got %+v, %v", obj, err)
+	}
+	since := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	res, _ := s.ObjectsContext(context.Background(), ObjectsOptions{MetadataDate: &since})
+	if !reflect.DeepEqual(res.ObjectIDs, []int{1}) {
+		t.Errorf("MetadataDate should match objects updated after its day: %v", res.ObjectIDs)
+	}
+}
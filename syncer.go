@@ -0,0 +1,225 @@
+package met
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SyncEventKind classifies a SyncEvent.
+type SyncEventKind int
+
+const (
+	// ObjectAdded reports an object that was not listed at the last sync.
+	ObjectAdded SyncEventKind = iota + 1
+	// ObjectUpdated reports an object whose metadata changed since the last
+	// sync.
+	ObjectUpdated
+	// ObjectRemoved reports an object that is no longer listed.
+	ObjectRemoved
+)
+
+func (k SyncEventKind) String() string {
+	switch k {
+	case ObjectAdded:
+		return "added"
+	case ObjectUpdated:
+		return "updated"
+	case ObjectRemoved:
+		return "removed"
+	}
+	return fmt.Sprintf("SyncEventKind(%d)", int(k))
+}
+
+// SyncEvent describes a change to the collection observed by a Syncer.
+type SyncEvent struct {
+	Kind     SyncEventKind
+	ObjectID int
+	// Object is the object's current record, or nil for ObjectRemoved events.
+	Object *ObjectResult
+}
+
+// SyncState is the state a Syncer carries between syncs.
+type SyncState struct {
+	// LastSync is the start time of the last successful sync, or the zero time
+	// if there has been none.
+	LastSync time.Time `json:"lastSync"`
+	// ObjectIDs lists the objects present at the last successful sync.
+	ObjectIDs []int `json:"objectIDs"`
+}
+
+// Syncer incrementally tracks changes to the collection. Each sync lists the
+// objects updated since the last sync using ObjectsOptions.MetadataDate,
+// fetches their records, and detects removals by diffing the full listing
+// against the previous one.
+//
+// The first sync reports every listed object as added; use a Harvester to
+// mirror the collection initially, then seed State with the harvested IDs
+// and harvest start time.
+type Syncer struct {
+	// Client fetches objects.
+	Client *Client
+	// DepartmentIDs, if non-empty, restricts the sync to objects in the
+	// specified departments.
+	DepartmentIDs []int
+	// OnEvent is called for each change. If it returns an error, the sync
+	// stops and State is not updated.
+	OnEvent func(SyncEvent) error
+	// State is the Syncer's state, updated after each successful sync.
+	State SyncState
+	// StatePath, if specified, is a file from which Sync loads State before
+	// syncing and to which it saves State after a successful sync.
+	StatePath string
+	// Concurrency is the maximum number of concurrent Object requests. If
+	// unspecified, DefaultConcurrency is used.
+	Concurrency int
+}
+
+// Sync reports the changes since the last successful sync to OnEvent, then
+// updates State. If Sync fails, State is unchanged and the next sync reports
+// the same changes again.
+func (s *Syncer) Sync(ctx context.Context) error {
+	if err := s.loadState(); err != nil {
+		return err
+	}
+	start := time.Now()
+
+	current, err := s.Client.ObjectsContext(ctx, ObjectsOptions{DepartmentIDs: s.DepartmentIDs})
+	if err != nil {
+		return fmt.Errorf("failed listing objects: %w", err)
+	}
+	known := make(map[int]bool, len(s.State.ObjectIDs))
+	for _, id := range s.State.ObjectIDs {
+		known[id] = true
+	}
+	changed := map[int]bool{}
+	if !s.State.LastSync.IsZero() {
+		// MetadataDate matches objects updated after its day, so query from
+		// the day before the last sync to include objects updated later on
+		// the same day. The extra day also absorbs time zone differences
+		// with the server.
+		y, m, d := s.State.LastSync.AddDate(0, 0, -1).Date()
+		since := time.Date(y, m, d, 0, 0, 0, 0, s.State.LastSync.Location())
+		updated, err := s.Client.ObjectsContext(ctx, ObjectsOptions{MetadataDate: &since, DepartmentIDs: s.DepartmentIDs})
+		if err != nil {
+			return fmt.Errorf("failed listing updated objects: %w", err)
+		}
+		for _, id := range updated.ObjectIDs {
+			changed[id] = true
+		}
+	}
+
+	// Fetch every new object and every known object with updated metadata.
+	listed := make(map[int]bool, len(current.ObjectIDs))
+	var fetch []int
+	for _, id := range current.ObjectIDs {
+		listed[id] = true
+		if !known[id] || changed[id] {
+			fetch = append(fetch, id)
+		}
+	}
+	results, err := s.Client.ObjectsByID(ctx, fetch, ObjectsByIDOptions{Concurrency: s.Concurrency})
+	if err != nil {
+		return err
+	}
+	// missing lists new objects deleted between listing and fetching. They
+	// are left out of State, having never been reported as added.
+	missing := map[int]bool{}
+	for _, result := range results {
+		if errors.Is(result.Err, ErrObjectNotFound) {
+			// Known objects stay in State, so the next sync reports their
+			// removal.
+			if !known[result.ObjectID] {
+				missing[result.ObjectID] = true
+			}
+			continue
+		} else if result.Err != nil {
+			return fmt.Errorf("failed fetching object %d: %w", result.ObjectID, result.Err)
+		}
+		kind := ObjectAdded
+		if known[result.ObjectID] {
+			kind = ObjectUpdated
+		}
+		if err := s.emit(SyncEvent{Kind: kind, ObjectID: result.ObjectID, Object: result.Object}); err != nil {
+			return err
+		}
+	}
+	for _, id := range s.State.ObjectIDs {
+		if !listed[id] {
+			if err := s.emit(SyncEvent{Kind: ObjectRemoved, ObjectID: id}); err != nil {
+				return err
+			}
+		}
+	}
+
+	previous := s.State
+	ids := make([]int, 0, len(current.ObjectIDs))
+	for _, id := range current.ObjectIDs {
+		if !missing[id] {
+			ids = append(ids, id)
+		}
+	}
+	s.State = SyncState{LastSync: start, ObjectIDs: ids}
+	if err := s.saveState(); err != nil {
+		s.State = previous
+		return err
+	}
+	return nil
+}
+
+func (s *Syncer) emit(event SyncEvent) error {
+	if s.OnEvent == nil {
+		return nil
+	}
+	return s.OnEvent(event)
+}
+
+// loadState reads State from StatePath, if it exists.
+func (s *Syncer) loadState() error {
+	if s.StatePath == "" {
+		return nil
+	}
+	b, err := os.ReadFile(s.StatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed reading sync state: %w", err)
+	}
+	var state SyncState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return fmt.Errorf("failed decoding sync state: %w", err)
+	}
+	s.State = state
+	return nil
+}
+
+// saveState atomically writes State to StatePath.
+func (s *Syncer) saveState() error {
+	if s.StatePath == "" {
+		return nil
+	}
+	b, err := json.Marshal(s.State)
+	if err != nil {
+		return fmt.Errorf("failed encoding sync state: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.StatePath), filepath.Base(s.StatePath)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed writing sync state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed writing sync state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed writing sync state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.StatePath); err != nil {
+		return fmt.Errorf("failed writing sync state: %w", err)
+	}
+	return nil
+}
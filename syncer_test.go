@@ -0,0 +1,129 @@
+package met
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSyncer(t *testing.T) {
+	listing := `{"total": 3, "objectIDs": [1, 2, 3]}`
+	updated := `{"total": 0, "objectIDs": null}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/objects" && r.URL.Query().Get("metadataDate") != "":
+			fmt.Fprint(w, updated)
+		case r.URL.Path == "/objects":
+			fmt.Fprint(w, listing)
+		default:
+			var id int
+			fmt.Sscanf(r.URL.Path, "/objects/%d", &id)
+			fmt.Fprintf(w, `{"objectID": %d}`, id)
+		}
+	}))
+	defer server.Close()
+
+	var events []string
+	newSyncer := func() *Syncer {
+		return &Syncer{
+			Client:    newTestClient(t, server),
+			StatePath: filepath.Join(t.TempDir(), "state.json"),
+			OnEvent: func(e SyncEvent) error {
+				events = append(events, fmt.Sprintf("%s %d", e.Kind, e.ObjectID))
+				return nil
+			},
+		}
+	}
+	sync := func(s *Syncer) []string {
+		t.Helper()
+		events = nil
+		if err := s.Sync(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		sort.Strings(events)
+		return events
+	}
+
+	s := newSyncer()
+	if got := sync(s); !reflect.DeepEqual(got, []string{"added 1", "added 2", "added 3"}) {
+		t.Errorf("Unexpected initial events: %v", got)
+	}
+
+	listing = `{"total": 3, "objectIDs": [2, 3, 4]}`
+	updated = `{"total": 2, "objectIDs": [3, 4]}`
+	// A fresh Syncer sharing the state file picks up where the last left off.
+	resumed := newSyncer()
+	resumed.StatePath = s.StatePath
+	if got := sync(resumed); !reflect.DeepEqual(got, []string{"added 4", "removed 1", "updated 3"}) {
+		t.Errorf("Unexpected incremental events: %v", got)
+	}
+	if !reflect.DeepEqual(resumed.State.ObjectIDs, []int{2, 3, 4}) || !resumed.State.LastSync.After(s.State.LastSync) {
+		t.Errorf("Unexpected state after sync: %+v", resumed.State)
+	}
+}
+
+// memorySourceServer serves /objects and /objects/{id} from s, honoring
+// metadataDate. ghosts are listed by /objects but not found by /objects/{id}.
+func memorySourceServer(s *MemorySource, ghosts ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/objects" {
+			var options ObjectsOptions
+			if v := r.URL.Query().Get("metadataDate"); v != "" {
+				date, _ := time.Parse("2006-01-02", v)
+				options.MetadataDate = &date
+			}
+			res, _ := s.ObjectsContext(r.Context(), options)
+			res.ObjectIDs = append(res.ObjectIDs, ghosts...)
+			res.Total = len(res.ObjectIDs)
+			json.NewEncoder(w).Encode(res)
+			return
+		}
+		var id int
+		fmt.Sscanf(r.URL.Path, "/objects/%d", &id)
+		obj, err := s.ObjectContext(r.Context(), ObjectOptions{ObjectID: id})
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(obj)
+	}))
+}
+
+func TestSyncerSameDayUpdates(t *testing.T) {
+	lastSync := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	s := NewMemorySource([]*ObjectResult{
+		{ObjectID: 1, MetadataDate: "2024-05-01T00:00:00Z"},
+		// Updated later on the day of the last sync.
+		{ObjectID: 2, MetadataDate: "2024-06-01T15:00:00Z"},
+	}, nil)
+	server := memorySourceServer(s, 3)
+	defer server.Close()
+
+	var events []string
+	syncer := &Syncer{
+		Client: newTestClient(t, server),
+		State:  SyncState{LastSync: lastSync, ObjectIDs: []int{1, 2}},
+		OnEvent: func(e SyncEvent) error {
+			events = append(events, fmt.Sprintf("%s %d", e.Kind, e.ObjectID))
+			return nil
+		},
+	}
+	if err := syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(events, []string{"updated 2"}) {
+		t.Errorf("Unexpected events: %v", events)
+	}
+	// Object 3 was listed but not found, so it was never reported as added
+	// and must not be reported as removed later.
+	if !reflect.DeepEqual(syncer.State.ObjectIDs, []int{1, 2}) {
+		t.Errorf("Unexpected state after sync: %+v", syncer.State)
+	}
+}
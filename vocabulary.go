@@ -0,0 +1,236 @@
+package met
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Vocabulary is a set of known values for a free-form search parameter, such
+// as SearchOptions.Media or SearchOptions.GeoLocations. Values are compared
+// case-insensitively. The zero Vocabulary is empty and ready to use, and
+// Vocabulary is safe for concurrent use.
+type Vocabulary struct {
+	mu sync.RWMutex
+	// values maps lowercase values to their canonical spellings.
+	values map[string]string
+}
+
+// NewVocabulary returns a Vocabulary of values.
+func NewVocabulary(values ...string) *Vocabulary {
+	v := &Vocabulary{}
+	v.Add(values...)
+	return v
+}
+
+// Add adds values to the vocabulary. Empty values are ignored, and a value
+// already present keeps its first spelling.
+func (v *Vocabulary) Add(values ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.values == nil {
+		v.values = make(map[string]string, len(values))
+	}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		key := strings.ToLower(value)
+		if _, ok := v.values[key]; !ok && value != "" {
+			v.values[key] = value
+		}
+	}
+}
+
+// Len returns the number of values in the vocabulary.
+func (v *Vocabulary) Len() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return len(v.values)
+}
+
+// Values returns the vocabulary's values in sorted order.
+func (v *Vocabulary) Values() []string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	values := make([]string, 0, len(v.values))
+	for _, value := range v.values {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// Canonical returns the vocabulary's spelling of value, and false if value is
+// unknown.
+func (v *Vocabulary) Canonical(value string) (string, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	canonical, ok := v.values[strings.ToLower(strings.TrimSpace(value))]
+	return canonical, ok
+}
+
+// Contains reports whether value is in the vocabulary.
+func (v *Vocabulary) Contains(value string) bool {
+	_, ok := v.Canonical(value)
+	return ok
+}
+
+// Suggest returns the known value closest in spelling to value, for "Did you
+// mean" messages, and false if no value is close enough to suggest. A known
+// value is its own suggestion.
+func (v *Vocabulary) Suggest(value string) (string, bool) {
+	key := []rune(strings.ToLower(strings.TrimSpace(value)))
+	// Allow roughly one edit for every three characters.
+	best, bestDistance := "", len(key)/3+1
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	for candidate, canonical := range v.values {
+		d := editDistance(key, []rune(candidate))
+		if d < bestDistance || (d == bestDistance && best != "" && canonical < best) {
+			best, bestDistance = canonical, d
+		}
+	}
+	return best, best != ""
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// UnknownValueError describes a search parameter value absent from its
+// Vocabulary. It matches ErrInvalidSearch with errors.Is.
+type UnknownValueError struct {
+	// Parameter is the search parameter, "medium" or "geoLocations".
+	Parameter string
+	// Value is the unknown value.
+	Value string
+	// Suggestion is the closest known value, or empty if none is close.
+	Suggestion string
+}
+
+// Error implements the error interface.
+func (e *UnknownValueError) Error() string {
+	msg := fmt.Sprintf("unknown %s %q", e.Parameter, e.Value)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf("; did you mean %q?", e.Suggestion)
+	}
+	return msg
+}
+
+// Is reports whether target is ErrInvalidSearch.
+func (e *UnknownValueError) Is(target error) bool {
+	return target == ErrInvalidSearch
+}
+
+// Vocabularies are the known values for SearchOptions.Media and
+// SearchOptions.GeoLocations. Nil vocabularies accept every value.
+type Vocabularies struct {
+	Media        *Vocabulary
+	GeoLocations *Vocabulary
+}
+
+// MetVocabularies returns Vocabularies of common Media and GeoLocations values
+// in the Met collection: its principal classifications, and continents,
+// regions, and countries. They are not exhaustive; add values harvested from
+// the collection with AddObject or BuildVocabularies.
+func MetVocabularies() Vocabularies {
+	return Vocabularies{
+		Media:        NewVocabulary(metMedia...),
+		GeoLocations: NewVocabulary(metGeoLocations...),
+	}
+}
+
+// BuildVocabularies returns Vocabularies of the values found in the objects
+// yielded by seq, stopping at the first error. See Vocabularies.AddObject.
+func BuildVocabularies(seq iter.Seq2[*ObjectResult, error]) (Vocabularies, error) {
+	vs := Vocabularies{Media: NewVocabulary(), GeoLocations: NewVocabulary()}
+	for obj, err := range seq {
+		if err != nil {
+			return vs, err
+		}
+		vs.AddObject(obj)
+	}
+	return vs, nil
+}
+
+// AddObject adds obj's values to the vocabularies. Media values are taken
+// from Classification, including each part of hyphenated classifications
+// like "Faience-Sculpture", and from the semicolon- or comma-separated parts
+// of Medium. GeoLocations values are taken from City, State, Country, Region,
+// and Subregion.
+func (vs Vocabularies) AddObject(obj *ObjectResult) {
+	if vs.Media != nil {
+		vs.Media.Add(obj.Classification)
+		if strings.Contains(obj.Classification, "-") {
+			vs.Media.Add(strings.Split(obj.Classification, "-")...)
+		}
+		vs.Media.Add(strings.FieldsFunc(obj.Medium, func(r rune) bool { return r == ';' || r == ',' })...)
+	}
+	if vs.GeoLocations != nil {
+		vs.GeoLocations.Add(obj.City, obj.State, obj.Country, obj.Region, obj.Subregion)
+	}
+}
+
+// Check returns an error if options.Media or options.GeoLocations contain
+// values absent from the corresponding vocabulary. The error joins an
+// *UnknownValueError for each unknown value, and matches ErrInvalidSearch.
+func (vs Vocabularies) Check(options SearchOptions) error {
+	var errs []error
+	check := func(parameter string, v *Vocabulary, values []string) {
+		if v == nil {
+			return
+		}
+		for _, value := range values {
+			if !v.Contains(value) {
+				suggestion, _ := v.Suggest(value)
+				errs = append(errs, &UnknownValueError{Parameter: parameter, Value: value, Suggestion: suggestion})
+			}
+		}
+	}
+	check("medium", vs.Media, options.Media)
+	check("geoLocations", vs.GeoLocations, options.GeoLocations)
+	return errors.Join(errs...)
+}
+
+var metMedia = []string{
+	"Arms", "Armor", "Books", "Bronzes", "Ceramics", "Coins", "Costumes",
+	"Drawings", "Enamels", "Furniture", "Gems", "Glass", "Ivories",
+	"Jewelry", "Lacquer", "Manuscripts and Illuminations", "Metalwork",
+	"Musical Instruments", "Paintings", "Photographs", "Prints", "Sculpture",
+	"Silver", "Textiles", "Vases", "Woodwork",
+}
+
+var metGeoLocations = []string{
+	// Continents and regions.
+	"Africa", "Americas", "Asia", "Central Asia", "East Asia", "Europe",
+	"Mesoamerica", "Middle East", "North America", "Oceania", "South America",
+	"South Asia", "Southeast Asia",
+	// Countries.
+	"Afghanistan", "Austria", "Belgium", "Cambodia", "Canada", "China",
+	"Cyprus", "Egypt", "England", "France", "Germany", "Greece", "India",
+	"Indonesia", "Iran", "Iraq", "Italy", "Japan", "Korea", "Mexico",
+	"Netherlands", "Nigeria", "Pakistan", "Peru", "Russia", "Scotland",
+	"Spain", "Switzerland", "Syria", "Thailand", "Turkey", "United States",
+	// Cities and states.
+	"Boston", "London", "New York", "Paris", "Philadelphia", "Rome",
+	"Venice", "Vienna",
+}
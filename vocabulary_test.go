@@ -0,0 +1,94 @@
+package met
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestVocabularySuggest(t *testing.T) {
+	v := NewVocabulary("Paintings", "Prints", "Sculpture", "Textiles")
+	cases := []struct {
+		value      string
+		suggestion string
+	}{
+		{"paintings", "Paintings"},
+		{"Paintngs", "Paintings"},
+		{"sculptures", "Sculpture"},
+		{"Prnts", "Prints"},
+		{"Furniture", ""},
+		{"", ""},
+	}
+	for _, tc := range cases {
+		if got, ok := v.Suggest(tc.value); got != tc.suggestion || ok != (tc.suggestion != "") {
+			t.Errorf("%q: expected suggestion %q, got %q", tc.value, tc.suggestion, got)
+		}
+	}
+	if !v.Contains(" PRINTS ") || v.Contains("Print") {
+		t.Errorf("Unexpected Contains results")
+	}
+}
+
+func TestBuildVocabularies(t *testing.T) {
+	objects := []*ObjectResult{
+		{Classification: "Faience-Sculpture", Medium: "Faience", Country: "Egypt", Region: "Middle Egypt"},
+		{Classification: "Paintings", Medium: "Hanging scroll; ink and color on silk", City: "Kyoto"},
+	}
+	seq := func(yield func(*ObjectResult, error) bool) {
+		for _, obj := range objects {
+			if !yield(obj, nil) {
+				return
+			}
+		}
+	}
+	vs, err := BuildVocabularies(seq)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"Faience", "Faience-Sculpture", "Hanging scroll", "Paintings", "Sculpture", "ink and color on silk"}
+	if got := vs.Media.Values(); !slices.Equal(got, expected) {
+		t.Errorf("Expected media %v, got %v", expected, got)
+	}
+	expected = []string{"Egypt", "Kyoto", "Middle Egypt"}
+	if got := vs.GeoLocations.Values(); !slices.Equal(got, expected) {
+		t.Errorf("Expected geolocations %v, got %v", expected, got)
+	}
+}
+
+func TestClientStrictVocabularies(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"total": 0, "objectIDs": null}`))
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+	vs := MetVocabularies()
+	c.Vocabularies = &vs
+
+	_, err := c.Search(SearchOptions{Q: "cat", Media: []string{"Paintngs"}, GeoLocations: []string{"France", "Atlantis"}})
+	if !errors.Is(err, ErrInvalidSearch) {
+		t.Fatalf("Expected invalid search error, got: %v", err)
+	}
+	var unknown *UnknownValueError
+	if !errors.As(err, &unknown) || unknown.Value != "Paintngs" || unknown.Suggestion != "Paintings" {
+		t.Errorf("Unexpected unknown value error: %#v", unknown)
+	}
+	if msg := err.Error(); !strings.Contains(msg, `did you mean "Paintings"?`) || !strings.Contains(msg, `unknown geoLocations "Atlantis"`) {
+		t.Errorf("Unexpected error message: %s", msg)
+	}
+	if n := atomic.LoadInt32(&requests); n != 0 {
+		t.Errorf("Expected no requests for unknown values, got %d", n)
+	}
+
+	if _, err := c.Search(SearchOptions{Q: "cat", Media: []string{"paintings"}, GeoLocations: []string{"Paris"}}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("Expected 1 request, got %d", n)
+	}
+}
@@ -0,0 +1,152 @@
+package met
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Bounds substituted for the open ends of a YearRange in search requests,
+// since the Met API requires both dateBegin and dateEnd. They lie beyond the
+// dates of any object in the collection.
+const (
+	openYearBegin = -1000000
+	openYearEnd   = 100000
+)
+
+// YearRange is a range of years, inclusive of both ends, as used by
+// SearchOptions.YearRange. Years are integers as in ObjectResult's
+// ObjectBeginDate and ObjectEndDate: negative years are BCE. Either end may be
+// open; the zero YearRange contains every year.
+//
+// YearRange values are comparable, and round-trip through JSON as an object
+// with optional "begin" and "end" fields.
+type YearRange struct {
+	begin, end       int
+	hasBegin, hasEnd bool
+}
+
+// NewYearRange returns the range of years from yearBegin to yearEnd, for use in
+// SearchOptions.
+func NewYearRange(yearBegin, yearEnd int) *YearRange {
+	return &YearRange{begin: yearBegin, end: yearEnd, hasBegin: true, hasEnd: true}
+}
+
+// Since returns the range of years from yearBegin onward.
+func Since(yearBegin int) *YearRange {
+	return &YearRange{begin: yearBegin, hasBegin: true}
+}
+
+// Until returns the range of years up to and including yearEnd, e.g.
+// Until(-500) for objects dated 500 BCE or earlier.
+func Until(yearEnd int) *YearRange {
+	return &YearRange{end: yearEnd, hasEnd: true}
+}
+
+// Century returns the range of years in the nth century, following the Met's
+// dating: Century(19) is 1800 to 1899, and Century(-5), the 5th century BCE, is
+// -500 to -401. Century panics if n is zero.
+func Century(n int) *YearRange {
+	switch {
+	case n > 0:
+		return NewYearRange((n-1)*100, n*100-1)
+	case n < 0:
+		return NewYearRange(n*100, n*100+99)
+	}
+	panic("met: there is no century zero")
+}
+
+// Decade returns the ten years beginning with the multiple of ten at or before
+// year, e.g. 1880 to 1889 for Decade(1885).
+func Decade(year int) *YearRange {
+	begin := year - year%10
+	if year < 0 && year%10 != 0 {
+		begin -= 10
+	}
+	return NewYearRange(begin, begin+9)
+}
+
+// Begin returns the first year in r, and false if r's beginning is open.
+func (r YearRange) Begin() (int, bool) {
+	return r.begin, r.hasBegin
+}
+
+// End returns the last year in r, and false if r's end is open.
+func (r YearRange) End() (int, bool) {
+	return r.end, r.hasEnd
+}
+
+// IsEmpty reports whether r contains no years because it ends before it
+// begins.
+func (r YearRange) IsEmpty() bool {
+	return r.hasBegin && r.hasEnd && r.begin > r.end
+}
+
+// Contains reports whether obj's dates, from ObjectBeginDate to ObjectEndDate,
+// overlap r.
+func (r YearRange) Contains(obj *ObjectResult) bool {
+	if r.hasBegin && obj.ObjectEndDate < r.begin {
+		return false
+	}
+	if r.hasEnd && obj.ObjectBeginDate > r.end {
+		return false
+	}
+	return true
+}
+
+// bounds returns the ends of r, substituting bounds for open ends.
+func (r YearRange) bounds() (begin, end int) {
+	begin, end = openYearBegin, openYearEnd
+	if r.hasBegin {
+		begin = r.begin
+	}
+	if r.hasEnd {
+		end = r.end
+	}
+	return begin, end
+}
+
+// String implements fmt.Stringer.
+func (r YearRange) String() string {
+	switch {
+	case r.hasBegin && r.hasEnd:
+		return fmt.Sprintf("%d to %d", r.begin, r.end)
+	case r.hasBegin:
+		return fmt.Sprintf("since %d", r.begin)
+	case r.hasEnd:
+		return fmt.Sprintf("until %d", r.end)
+	}
+	return "any year"
+}
+
+type yearRangeJSON struct {
+	Begin *int `json:"begin,omitempty"`
+	End   *int `json:"end,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. Open ends are omitted.
+func (r YearRange) MarshalJSON() ([]byte, error) {
+	var v yearRangeJSON
+	if r.hasBegin {
+		v.Begin = &r.begin
+	}
+	if r.hasEnd {
+		v.End = &r.end
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Absent or null ends are open.
+func (r *YearRange) UnmarshalJSON(data []byte) error {
+	var v yearRangeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*r = YearRange{}
+	if v.Begin != nil {
+		r.begin, r.hasBegin = *v.Begin, true
+	}
+	if v.End != nil {
+		r.end, r.hasEnd = *v.End, true
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package met
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestYearRangeConstructors(t *testing.T) {
+	cases := []struct {
+		r          *YearRange
+		begin, end int
+	}{
+		{NewYearRange(-500, 1900), -500, 1900},
+		{Century(19), 1800, 1899},
+		{Century(1), 0, 99},
+		{Century(-5), -500, -401},
+		{Decade(1885), 1880, 1889},
+		{Decade(1880), 1880, 1889},
+		{Decade(-505), -510, -501},
+	}
+	for _, tc := range cases {
+		begin, hasBegin := tc.r.Begin()
+		end, hasEnd := tc.r.End()
+		if !hasBegin || !hasEnd || begin != tc.begin || end != tc.end {
+			t.Errorf("Expected %d to %d, got %v", tc.begin, tc.end, tc.r)
+		}
+	}
+	if _, ok := Since(1900).End(); ok {
+		t.Errorf("Expected open end")
+	}
+	if _, ok := Until(-500).Begin(); ok {
+		t.Errorf("Expected open beginning")
+	}
+	if *Century(19) != *NewYearRange(1800, 1899) {
+		t.Errorf("Expected equal ranges to compare equal")
+	}
+}
+
+func TestYearRangeContains(t *testing.T) {
+	hippo := &ObjectResult{ObjectBeginDate: -1961, ObjectEndDate: -1878}
+	quilt := &ObjectResult{ObjectBeginDate: 1825, ObjectEndDate: 1835}
+	cases := []struct {
+		r            *YearRange
+		hippo, quilt bool
+	}{
+		{Until(-500), true, false},
+		{Since(1900), false, false},
+		{Since(1830), false, true},
+		{Century(-19), true, false},
+		{Century(19), false, true},
+		{Decade(1830), false, true},
+		{&YearRange{}, true, true},
+	}
+	for _, tc := range cases {
+		if got := tc.r.Contains(hippo); got != tc.hippo {
+			t.Errorf("%v: expected Contains(hippo) %t", tc.r, tc.hippo)
+		}
+		if got := tc.r.Contains(quilt); got != tc.quilt {
+			t.Errorf("%v: expected Contains(quilt) %t", tc.r, tc.quilt)
+		}
+	}
+}
+
+func TestYearRangeJSON(t *testing.T) {
+	cases := []struct {
+		r    *YearRange
+		json string
+	}{
+		{NewYearRange(-500, 1900), `{"begin":-500,"end":1900}`},
+		{Since(0), `{"begin":0}`},
+		{Until(-500), `{"end":-500}`},
+		{&YearRange{}, `{}`},
+	}
+	for _, tc := range cases {
+		data, err := json.Marshal(tc.r)
+		if err != nil || string(data) != tc.json {
+			t.Errorf("Expected %s, got %s (%v)", tc.json, data, err)
+			continue
+		}
+		var decoded YearRange
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != *tc.r {
+			t.Errorf("Expected %v to round-trip, got %v (%v)", tc.r, decoded, err)
+		}
+	}
+}
+
+func TestYearRangeQuery(t *testing.T) {
+	cases := []struct {
+		r     *YearRange
+		query string
+	}{
+		{NewYearRange(1700, 1800), "dateBegin=1700&dateEnd=1800&q=%2A"},
+		{Until(-500), "dateBegin=-1000000&dateEnd=-500&q=%2A"},
+		{Since(1900), "dateBegin=1900&dateEnd=100000&q=%2A"},
+	}
+	for _, tc := range cases {
+		if q := (SearchOptions{Q: "*", YearRange: tc.r}).toQuery().Encode(); q != tc.query {
+			t.Errorf("Expected %s, got %s", tc.query, q)
+		}
+	}
+}